@@ -1 +1,75 @@
-package app
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/server"
+	"github.com/iamaul/go-evonix-backend-api/pkg/database/mysql"
+	"github.com/iamaul/go-evonix-backend-api/pkg/database/redis"
+	"github.com/iamaul/go-evonix-backend-api/pkg/geoip"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/mailer"
+	"github.com/iamaul/go-evonix-backend-api/pkg/metrics"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+)
+
+func main() {
+	log.Println("Starting api server")
+
+	configPath := utils.GetConfigPath(os.Getenv("config"))
+
+	cfgFile, err := config.LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("LoadConfig: %v", err)
+	}
+
+	cfg, err := config.ParseConfig(cfgFile)
+	if err != nil {
+		log.Fatalf("ParseConfig: %v", err)
+	}
+
+	appLogger := logger.NewZapLogger(cfg)
+	appLogger.InitLogger()
+	appLogger.Infof("AppVersion: %s, LogLevel: %s, Mode: %s, SSL: %v", cfg.Server.AppVersion, cfg.Logger.Level, cfg.Server.Mode, cfg.Server.SSL)
+
+	mysqlDB, err := mysql.NewMysqlDB(cfg)
+	if err != nil {
+		appLogger.Fatalf("MySQL init: %s", err)
+	}
+	appLogger.Infof("MySQL connected, Status: %#v", mysqlDB.Stats())
+
+	redisClient := redis.NewRedisClient(cfg)
+	appLogger.Info("Redis connected")
+
+	tp, err := otel.JaegerTelemetry(cfg)
+	if err != nil {
+		appLogger.Fatalf("Jaeger init: %s", err)
+	}
+	appLogger.Info("Jaeger connected")
+
+	metric, err := metrics.CreateMetrics(cfg.Metrics.ServiceName)
+	if err != nil {
+		appLogger.Fatalf("CreateMetrics: %s", err)
+	}
+	appLogger.Infof("Metrics available URL: %s, ServiceName: %s", cfg.Metrics.URL, cfg.Metrics.ServiceName)
+
+	mail, err := mailer.NewMailer(cfg.Mailer, appLogger)
+	if err != nil {
+		appLogger.Fatalf("Mailer init: %s", err)
+	}
+	mailQueue := mailer.NewQueue(mail, cfg.Mailer, appLogger)
+	appLogger.Infof("Mailer started, Driver: %s", cfg.Mailer.Driver)
+
+	geoIP, err := geoip.NewReader(cfg.GeoIP, appLogger)
+	if err != nil {
+		appLogger.Fatalf("GeoIP init: %s", err)
+	}
+
+	s := server.NewServer(cfg, mysqlDB, redisClient, tp, metric, mailQueue, geoIP, appLogger)
+	if err = s.Run(); err != nil {
+		appLogger.Fatal(err)
+	}
+}
@@ -10,15 +10,30 @@ import (
 
 type (
 	Config struct {
-		Server      ServerConfig
-		Mysql       MysqlConfig
-		Redis       RedisConfig
-		Cookie      Cookie
-		Session     Session
-		Metrics     Metrics
-		Logger      Logger
-		FileStorage FileStorage
-		Jaeger      Jaeger
+		Server            ServerConfig
+		ClientIP          ClientIP
+		RateLimit         RateLimit
+		Mysql             MysqlConfig
+		Redis             RedisConfig
+		Cookie            Cookie
+		Session           Session
+		CSRF              CSRF
+		Metrics           Metrics
+		Logger            Logger
+		FileStorage       FileStorage
+		Jaeger            Jaeger
+		Jwt               Jwt
+		TwoFactor         TwoFactor
+		PasswordReset     PasswordReset
+		EmailVerification EmailVerification
+		Mailer            Mailer
+		LoginProtection   LoginProtection
+		PasswordHashing   PasswordHashing
+		PasswordPolicy    PasswordPolicy
+		Bans              Bans
+		GeoIP             GeoIP
+		Alts              Alts
+		Registration      Registration
 	}
 
 	ServerConfig struct {
@@ -28,7 +43,6 @@ type (
 		Mode              string
 		JwtSecretKey      string
 		CookieName        string
-		PasswordSalt      string
 		ReadTimeout       time.Duration
 		WriteTimeout      time.Duration
 		SSL               bool
@@ -37,6 +51,27 @@ type (
 		Debug             bool
 	}
 
+	// ClientIP forwarding headers are only read from TrustedProxies (CIDRs or addresses),
+	// Headers are tried in order: X-Forwarded-For, X-Real-IP and CF-Connecting-IP
+	ClientIP struct {
+		TrustedProxies []string
+		Headers        []string
+	}
+
+	// RateLimit rules are referenced by name from the routes, Key is ip or user
+	RateLimit struct {
+		Enabled bool
+		Prefix  string
+		Rules   []RateLimitRule
+	}
+
+	RateLimitRule struct {
+		Name   string
+		Limit  int
+		Period time.Duration
+		Key    string
+	}
+
 	MysqlConfig struct {
 		MysqlHost     string
 		MysqlPort     string
@@ -80,6 +115,13 @@ type (
 		Expire int
 	}
 
+	// CSRF tokens are only enforced when Server.CSRF is true, CookieName is the double-submit cookie
+	CSRF struct {
+		Secret     string
+		Expire     time.Duration
+		CookieName string
+	}
+
 	Metrics struct {
 		URL         string
 		ServiceName string
@@ -98,6 +140,134 @@ type (
 		ServiceName string
 		LogSpans    bool
 	}
+
+	Jwt struct {
+		Issuer             string
+		Audience           []string
+		AccessTokenExpire  time.Duration
+		RefreshTokenExpire time.Duration
+		RefreshPrefix      string
+		RevocationPrefix   string
+		SigningKeyID       string
+		Keys               []JwtKey
+	}
+
+	TwoFactor struct {
+		Issuer          string
+		ChallengePrefix string
+		ChallengeExpire time.Duration
+		MaxAttempts     int
+	}
+
+	PasswordReset struct {
+		Prefix string
+		Expire time.Duration
+		URL    string
+	}
+
+	EmailVerification struct {
+		Prefix         string
+		Expire         time.Duration
+		ResendCooldown time.Duration
+		URL            string
+	}
+
+	// Mailer Driver is smtp, file (writes .eml files to Directory) or log
+	Mailer struct {
+		Driver          string
+		Host            string
+		Port            int
+		Username        string
+		Password        string
+		From            string
+		FromName        string
+		ImplicitTLS     bool
+		Timeout         time.Duration
+		Directory       string
+		DefaultLanguage string
+		QueueSize       int
+		Workers         int
+		MaxRetries      int
+		RetryBackoff    time.Duration
+	}
+
+	// LoginProtection locks an account or IP out once its failures within FailureWindow reach
+	// the threshold, the lockout doubles with every further failure up to MaxLockout
+	LoginProtection struct {
+		Prefix                  string
+		AccountThreshold        int
+		IPThreshold             int
+		FailureWindow           time.Duration
+		BaseLockout             time.Duration
+		MaxLockout              time.Duration
+		SuspiciousAccountsPerIP int
+	}
+
+	// PasswordHashing Algorithm is argon2id or bcrypt, both verify the hashes of the other one.
+	// The pepper is applied to argon2id hashes only, changing it invalidates them.
+	PasswordHashing struct {
+		Algorithm   string
+		Pepper      string
+		Memory      uint32
+		Iterations  uint32
+		Parallelism uint8
+		SaltLength  uint32
+		KeyLength   uint32
+		BcryptCost  int
+	}
+
+	// PasswordPolicy MinScore is the zxcvbn score from 0 to 4, BreachedList is the path of a file
+	// with SHA-1 hashes of breached passwords, one per line, an empty path disables the check
+	PasswordPolicy struct {
+		MinLength     int
+		MaxLength     int
+		RequireLower  bool
+		RequireUpper  bool
+		RequireDigit  bool
+		RequireSymbol bool
+		MinScore      int
+		BreachedList  string
+	}
+
+	// Bans are reloaded from MySQL whenever a change is published on Channel,
+	// and every RefreshInterval in case a message was missed
+	Bans struct {
+		Channel         string
+		RefreshInterval time.Duration
+		AppealURL       string
+	}
+
+	// GeoIP databases are MaxMind .mmdb files, an empty path disables the lookups.
+	// The files are checked for changes every ReloadInterval.
+	GeoIP struct {
+		ASNDatabase    string
+		CityDatabase   string
+		ReloadInterval time.Duration
+	}
+
+	// Alts weights are how much a shared identifier of each kind says two accounts have the same owner,
+	// from 0 to 1. Identifiers shared by more than MaxSharedAccounts (cafés, CGNAT) are ignored.
+	Alts struct {
+		IPWeight          float64
+		SerialWeight      float64
+		FingerprintWeight float64
+		MaxSharedAccounts int
+		MinConfidence     float64
+	}
+
+	// Registration ReservedUsernames are refused as they are, StaffNameWords anywhere in a username,
+	// also when spelled with look-alike digits, so players can't pass as staff in-game
+	Registration struct {
+		ReservedUsernames []string
+		StaffNameWords    []string
+	}
+
+	JwtKey struct {
+		ID             string
+		Algorithm      string
+		PrivateKeyFile string
+		PublicKeyFile  string
+	}
 )
 
 func LoadConfig(filename string) (*viper.Viper, error) {
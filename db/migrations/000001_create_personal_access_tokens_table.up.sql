@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id           CHAR(36)     NOT NULL,
+    user_id      CHAR(36)     NOT NULL,
+    name         VARCHAR(64)  NOT NULL,
+    token_hash   VARCHAR(255) NOT NULL,
+    scopes       VARCHAR(255) NOT NULL,
+    last_used_at TIMESTAMP    NULL DEFAULT NULL,
+    expires_at   TIMESTAMP    NULL DEFAULT NULL,
+    revoked_at   TIMESTAMP    NULL DEFAULT NULL,
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_personal_access_tokens_user_id (user_id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
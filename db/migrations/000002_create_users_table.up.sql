@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS users (
+    id          CHAR(36)         NOT NULL,
+    username    VARCHAR(24)      NOT NULL,
+    email       VARCHAR(255)     NOT NULL,
+    password    VARCHAR(255)     NOT NULL,
+    admin_level TINYINT UNSIGNED NOT NULL DEFAULT 0,
+    created_at  TIMESTAMP        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_users_username (username),
+    UNIQUE KEY uq_users_email (email)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
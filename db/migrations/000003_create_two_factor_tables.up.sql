@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS user_two_factor (
+    user_id        CHAR(36)    NOT NULL,
+    secret         VARCHAR(64) NOT NULL,
+    last_used_step BIGINT      NOT NULL DEFAULT 0,
+    enabled_at     TIMESTAMP   NULL DEFAULT NULL,
+    created_at     TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id         BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    user_id    CHAR(36)        NOT NULL,
+    code_hash  CHAR(64)        NOT NULL,
+    used_at    TIMESTAMP       NULL DEFAULT NULL,
+    created_at TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_user_recovery_codes_user_id (user_id, code_hash)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN email_verified_at TIMESTAMP    NULL DEFAULT NULL AFTER email,
+    ADD COLUMN pending_email     VARCHAR(255) NULL DEFAULT NULL AFTER email_verified_at;
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN password_algorithm VARCHAR(16) NULL DEFAULT NULL AFTER password,
+    ADD COLUMN password_salt      VARCHAR(64) NULL DEFAULT NULL AFTER password_algorithm;
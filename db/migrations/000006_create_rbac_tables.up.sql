@@ -0,0 +1,117 @@
+CREATE TABLE IF NOT EXISTS roles (
+    id       VARCHAR(32)  NOT NULL,
+    name     VARCHAR(64)  NOT NULL,
+    priority INT UNSIGNED NOT NULL,
+    PRIMARY KEY (id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id          VARCHAR(64)  NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    PRIMARY KEY (id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id       VARCHAR(32) NOT NULL,
+    permission_id VARCHAR(64) NOT NULL,
+    PRIMARY KEY (role_id, permission_id),
+    CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE,
+    CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission_id) REFERENCES permissions (id) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+-- Admin level roles (admin_1 to admin_6) are never stored here, they follow users.admin_level of the gamemode
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id    CHAR(36)    NOT NULL,
+    role_id    VARCHAR(32) NOT NULL,
+    granted_by CHAR(36)    NULL DEFAULT NULL,
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role_id),
+    INDEX idx_user_roles_role_id (role_id),
+    CONSTRAINT fk_user_roles_role FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+INSERT INTO roles (id, name, priority)
+VALUES ('player', 'Player', 0),
+       ('helper', 'Helper', 10),
+       ('moderator', 'Moderator', 20),
+       ('admin_1', 'Admin Level 1', 31),
+       ('admin_2', 'Admin Level 2', 32),
+       ('admin_3', 'Admin Level 3', 33),
+       ('admin_4', 'Admin Level 4', 34),
+       ('admin_5', 'Admin Level 5', 35),
+       ('admin_6', 'Admin Level 6', 36),
+       ('developer', 'Developer', 40),
+       ('owner', 'Owner', 50);
+
+INSERT INTO permissions (id, description)
+VALUES ('users:read', 'View accounts'),
+       ('bans:read', 'View bans'),
+       ('bans:write', 'Create and lift bans'),
+       ('sessions:read', 'View the sessions of any account'),
+       ('sessions:write', 'Kill the sessions of any account'),
+       ('lockouts:write', 'Clear login lockouts'),
+       ('twofactor:reset', 'Reset two-factor authentication'),
+       ('roles:read', 'View role assignments'),
+       ('roles:write', 'Assign and remove roles'),
+       ('mail:preview', 'Preview mail templates'),
+       ('*', 'Every permission');
+
+INSERT INTO role_permissions (role_id, permission_id)
+VALUES ('helper', 'users:read'),
+       ('helper', 'bans:read'),
+       ('moderator', 'users:read'),
+       ('moderator', 'bans:read'),
+       ('moderator', 'bans:write'),
+       ('moderator', 'lockouts:write'),
+       ('admin_1', 'users:read'),
+       ('admin_1', 'bans:read'),
+       ('admin_1', 'bans:write'),
+       ('admin_1', 'lockouts:write'),
+       ('admin_1', 'roles:read'),
+       ('admin_2', 'users:read'),
+       ('admin_2', 'bans:read'),
+       ('admin_2', 'bans:write'),
+       ('admin_2', 'lockouts:write'),
+       ('admin_2', 'roles:read'),
+       ('admin_3', 'users:read'),
+       ('admin_3', 'bans:read'),
+       ('admin_3', 'bans:write'),
+       ('admin_3', 'lockouts:write'),
+       ('admin_3', 'roles:read'),
+       ('admin_4', 'users:read'),
+       ('admin_4', 'bans:read'),
+       ('admin_4', 'bans:write'),
+       ('admin_4', 'lockouts:write'),
+       ('admin_4', 'roles:read'),
+       ('admin_4', 'sessions:read'),
+       ('admin_4', 'sessions:write'),
+       ('admin_4', 'twofactor:reset'),
+       ('admin_4', 'mail:preview'),
+       ('admin_5', 'users:read'),
+       ('admin_5', 'bans:read'),
+       ('admin_5', 'bans:write'),
+       ('admin_5', 'lockouts:write'),
+       ('admin_5', 'roles:read'),
+       ('admin_5', 'sessions:read'),
+       ('admin_5', 'sessions:write'),
+       ('admin_5', 'twofactor:reset'),
+       ('admin_5', 'mail:preview'),
+       ('admin_5', 'roles:write'),
+       ('admin_6', 'users:read'),
+       ('admin_6', 'bans:read'),
+       ('admin_6', 'bans:write'),
+       ('admin_6', 'lockouts:write'),
+       ('admin_6', 'roles:read'),
+       ('admin_6', 'sessions:read'),
+       ('admin_6', 'sessions:write'),
+       ('admin_6', 'twofactor:reset'),
+       ('admin_6', 'mail:preview'),
+       ('admin_6', 'roles:write'),
+       ('developer', 'users:read'),
+       ('developer', 'roles:read'),
+       ('developer', 'mail:preview'),
+       ('owner', '*');
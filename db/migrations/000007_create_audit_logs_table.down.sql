@@ -0,0 +1,4 @@
+DELETE FROM permissions WHERE id = 'audit:read';
+DROP TRIGGER IF EXISTS audit_logs_no_delete;
+DROP TRIGGER IF EXISTS audit_logs_no_update;
+DROP TABLE IF EXISTS audit_logs;
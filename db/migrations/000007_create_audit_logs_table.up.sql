@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id         BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    action     VARCHAR(64)     NOT NULL,
+    actor_id   CHAR(36)        NULL DEFAULT NULL,
+    target_id  CHAR(36)        NULL DEFAULT NULL,
+    ip         VARCHAR(45)     NOT NULL DEFAULT '',
+    user_agent VARCHAR(512)    NOT NULL DEFAULT '',
+    request_id VARCHAR(64)     NOT NULL DEFAULT '',
+    changes    JSON            NULL DEFAULT NULL,
+    created_at TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_audit_logs_target_id (target_id, created_at),
+    INDEX idx_audit_logs_actor_id (actor_id, created_at),
+    INDEX idx_audit_logs_action (action, created_at),
+    INDEX idx_audit_logs_ip (ip, created_at)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TRIGGER audit_logs_no_update BEFORE UPDATE ON audit_logs FOR EACH ROW
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_logs is append-only';
+
+CREATE TRIGGER audit_logs_no_delete BEFORE DELETE ON audit_logs FOR EACH ROW
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_logs is append-only';
+
+INSERT INTO permissions (id, description)
+VALUES ('audit:read', 'View the audit log');
+
+INSERT INTO role_permissions (role_id, permission_id)
+VALUES ('admin_1', 'audit:read'),
+       ('admin_2', 'audit:read'),
+       ('admin_3', 'audit:read'),
+       ('admin_4', 'audit:read'),
+       ('admin_5', 'audit:read'),
+       ('admin_6', 'audit:read'),
+       ('developer', 'audit:read');
@@ -0,0 +1,16 @@
+-- value is a normalized address for ip bans, a network address in CIDR notation for cidr bans
+-- and the AS number for asn bans. A NULL expires_at is a permanent ban.
+CREATE TABLE IF NOT EXISTS bans (
+    id         BIGINT UNSIGNED            NOT NULL AUTO_INCREMENT,
+    type       ENUM ('ip', 'cidr', 'asn') NOT NULL,
+    value      VARCHAR(64)                NOT NULL,
+    reason     VARCHAR(255)               NOT NULL,
+    banned_by  CHAR(36)                   NULL DEFAULT NULL,
+    expires_at TIMESTAMP                  NULL DEFAULT NULL,
+    created_at TIMESTAMP                  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP                  NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_bans_type_value (type, value),
+    INDEX idx_bans_expires_at (expires_at)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS login_history (
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    user_id         CHAR(36)        NOT NULL,
+    result          VARCHAR(32)     NOT NULL,
+    ip              VARCHAR(45)     NOT NULL DEFAULT '',
+    user_agent      VARCHAR(512)    NOT NULL DEFAULT '',
+    country_code    CHAR(2)         NOT NULL DEFAULT '',
+    country         VARCHAR(64)     NOT NULL DEFAULT '',
+    city            VARCHAR(128)    NOT NULL DEFAULT '',
+    asn             INT UNSIGNED    NULL DEFAULT NULL,
+    as_organization VARCHAR(255)    NOT NULL DEFAULT '',
+    created_at      TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_login_history_user_id (user_id, created_at),
+    INDEX idx_login_history_user_country (user_id, result, country_code),
+    INDEX idx_login_history_ip (ip, created_at),
+    INDEX idx_login_history_asn (asn, created_at)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,2 @@
+DELETE FROM permissions WHERE id = 'alts:read';
+DROP TABLE IF EXISTS user_identifiers;
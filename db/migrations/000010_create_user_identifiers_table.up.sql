@@ -0,0 +1,25 @@
+-- Identifiers seen on an account, used to link alt accounts. The UCP records the ip and the
+-- browser fingerprint of every login, the gamemode reports the gpci serial of every connection
+-- through the API, see 000012_add_alts_write_permission.
+CREATE TABLE IF NOT EXISTS user_identifiers (
+    user_id       CHAR(36)                           NOT NULL,
+    kind          ENUM ('ip', 'gpci', 'fingerprint') NOT NULL,
+    value         VARCHAR(128)                       NOT NULL,
+    seen_count    INT UNSIGNED                       NOT NULL DEFAULT 1,
+    first_seen_at TIMESTAMP                          NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at  TIMESTAMP                          NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, kind, value),
+    INDEX idx_user_identifiers_kind_value (kind, value)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+INSERT INTO permissions (id, description)
+VALUES ('alts:read', 'View linked accounts');
+
+INSERT INTO role_permissions (role_id, permission_id)
+VALUES ('admin_1', 'alts:read'),
+       ('admin_2', 'alts:read'),
+       ('admin_3', 'alts:read'),
+       ('admin_4', 'alts:read'),
+       ('admin_5', 'alts:read'),
+       ('admin_6', 'alts:read');
@@ -0,0 +1,2 @@
+ALTER TABLE personal_access_tokens
+    MODIFY token_hash VARCHAR(255) NOT NULL;
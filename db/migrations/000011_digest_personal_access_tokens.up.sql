@@ -0,0 +1,8 @@
+-- Token secrets are stored as hex SHA-256 digests now, tokens hashed with the password hasher can't be verified anymore
+UPDATE personal_access_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE revoked_at IS NULL
+  AND token_hash LIKE '$%';
+
+ALTER TABLE personal_access_tokens
+    MODIFY token_hash VARCHAR(255) NOT NULL COMMENT 'hex SHA-256 digest of the token secret';
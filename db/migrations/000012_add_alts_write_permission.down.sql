@@ -0,0 +1,2 @@
+DELETE FROM roles WHERE id = 'game_server';
+DELETE FROM permissions WHERE id = 'alts:write';
@@ -0,0 +1,11 @@
+-- The gamemode reports gpci serials through POST /api/v1/admin/users/:user_id/identifiers/gpci with a
+-- personal access token scoped to alts:write, created by an account holding the game_server role.
+-- Only the owner outranks the role, so no staff member can hand it out.
+INSERT INTO permissions (id, description)
+VALUES ('alts:write', 'Record identifiers that link accounts');
+
+INSERT INTO roles (id, name, priority)
+VALUES ('game_server', 'Game Server', 45);
+
+INSERT INTO role_permissions (role_id, permission_id)
+VALUES ('game_server', 'alts:write');
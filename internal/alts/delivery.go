@@ -0,0 +1,9 @@
+package alts
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Alts HTTP Handlers interface
+type Handlers interface {
+	GetGraph() echo.HandlerFunc
+	RecordSerial() echo.HandlerFunc
+}
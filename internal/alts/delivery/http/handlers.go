@@ -0,0 +1,75 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/alts"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Alts handlers
+type altsHandlers struct {
+	cfg    *config.Config
+	altsUC alts.UseCase
+	logger logger.Logger
+}
+
+// NewAltsHandlers Alts handlers constructor
+func NewAltsHandlers(cfg *config.Config, altsUC alts.UseCase, log logger.Logger) alts.Handlers {
+	return &altsHandlers{cfg: cfg, altsUC: altsUC, logger: log}
+}
+
+// GetGraph Get the accounts within 2 hops of a user with their confidence, for staff
+func (h *altsHandlers) GetGraph() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "altsHandlers.GetGraph")
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErr.NewBadRequestError(httpErr.ErrBadQueryParams.Error()))
+		}
+
+		graph, err := h.altsUC.GetGraph(ctx, userID, pq)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: graph, Success: true})
+	}
+}
+
+// RecordSerial Save a gpci serial of an account, for the gamemode
+func (h *altsHandlers) RecordSerial() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "altsHandlers.RecordSerial")
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		input := &models.RecordSerialRequest{}
+		if err = utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.altsUC.RecordSerial(ctx, userID, input.Serial); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
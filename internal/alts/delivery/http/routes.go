@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/internal/alts"
+	"github.com/iamaul/go-evonix-backend-api/internal/middleware"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MapAltsAdminRoutes Map the linked accounts routes of the staff tools, the gamemode reports
+// serials with a personal access token of a game_server account scoped to alts:write
+func MapAltsAdminRoutes(adminGroup *echo.Group, h alts.Handlers, mw *middleware.MiddlewareManager) {
+	adminGroup.GET("/users/:user_id/alts", h.GetGraph(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionAltsRead))
+	adminGroup.POST("/users/:user_id/identifiers/gpci", h.RecordSerial(), mw.ScopedAuthMiddleware(models.PermissionAltsWrite), mw.PermissionMiddleware(models.PermissionAltsWrite))
+}
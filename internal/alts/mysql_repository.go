@@ -0,0 +1,20 @@
+package alts
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Repository Alts MySQL repository interface
+type Repository interface {
+	// SaveIdentifier records an identifier seen on the account, seeing it again bumps its count
+	SaveIdentifier(ctx context.Context, userID uuid.UUID, kind, value string) error
+	// ListLinks returns the identifiers the users share with other accounts, leaving out
+	// those shared by more than maxShared accounts
+	ListLinks(ctx context.Context, userIDs []uuid.UUID, maxShared int) ([]*models.AccountLink, error)
+	// GetUsernames returns the usernames of the users by id
+	GetUsernames(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]string, error)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/alts"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Alts Repository
+type altsRepo struct {
+	db *sqlx.DB
+}
+
+// NewAltsRepository Alts Repository constructor
+func NewAltsRepository(db *sqlx.DB) alts.Repository {
+	return &altsRepo{db: db}
+}
+
+// SaveIdentifier Record an identifier seen on an account
+func (r *altsRepo) SaveIdentifier(ctx context.Context, userID uuid.UUID, kind, value string) error {
+	ctx, span := otel.Tracer.Start(ctx, "altsRepo.SaveIdentifier")
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, saveIdentifier, userID, kind, value); err != nil {
+		return errors.Wrap(err, "altsRepo.SaveIdentifier.ExecContext")
+	}
+
+	return nil
+}
+
+// ListLinks Get the identifiers the users share with other accounts
+func (r *altsRepo) ListLinks(ctx context.Context, userIDs []uuid.UUID, maxShared int) ([]*models.AccountLink, error) {
+	ctx, span := otel.Tracer.Start(ctx, "altsRepo.ListLinks")
+	defer span.End()
+
+	links := make([]*models.AccountLink, 0)
+	if len(userIDs) == 0 {
+		return links, nil
+	}
+
+	query, args, err := sqlx.In(listLinks, userIDs, maxShared, userIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "altsRepo.ListLinks.In")
+	}
+	if err = r.db.SelectContext(ctx, &links, r.db.Rebind(query), args...); err != nil {
+		return nil, errors.Wrap(err, "altsRepo.ListLinks.SelectContext")
+	}
+
+	return links, nil
+}
+
+// GetUsernames Get the usernames of users by id
+func (r *altsRepo) GetUsernames(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]string, error) {
+	ctx, span := otel.Tracer.Start(ctx, "altsRepo.GetUsernames")
+	defer span.End()
+
+	usernames := make(map[uuid.UUID]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return usernames, nil
+	}
+
+	query, args, err := sqlx.In(getUsernames, userIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "altsRepo.GetUsernames.In")
+	}
+	var users []struct {
+		ID       uuid.UUID `db:"id"`
+		Username string    `db:"username"`
+	}
+	if err = r.db.SelectContext(ctx, &users, r.db.Rebind(query), args...); err != nil {
+		return nil, errors.Wrap(err, "altsRepo.GetUsernames.SelectContext")
+	}
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+
+	return usernames, nil
+}
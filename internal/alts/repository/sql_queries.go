@@ -0,0 +1,20 @@
+package repository
+
+const (
+	saveIdentifier = `INSERT INTO user_identifiers (user_id, kind, value) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE last_seen_at = CURRENT_TIMESTAMP, seen_count = seen_count + 1`
+
+	// The identifiers of the users are counted first, so the self join only touches those
+	// that aren't shared too widely
+	listLinks = `SELECT a.user_id, b.user_id AS linked_user_id, a.kind, a.value, shared.shared_count
+		FROM user_identifiers a
+		JOIN (SELECT kind, value, COUNT(*) AS shared_count
+			FROM user_identifiers
+			WHERE (kind, value) IN (SELECT kind, value FROM user_identifiers WHERE user_id IN (?))
+			GROUP BY kind, value
+			HAVING COUNT(*) BETWEEN 2 AND ?) shared ON shared.kind = a.kind AND shared.value = a.value
+		JOIN user_identifiers b ON b.kind = a.kind AND b.value = a.value AND b.user_id <> a.user_id
+		WHERE a.user_id IN (?)`
+
+	getUsernames = `SELECT id, username FROM users WHERE id IN (?)`
+)
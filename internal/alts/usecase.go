@@ -0,0 +1,20 @@
+package alts
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// UseCase Alts UseCase interface
+type UseCase interface {
+	// RecordLogin saves the ip and browser fingerprint of the request on the account, errors are only logged
+	RecordLogin(ctx context.Context, userID uuid.UUID)
+	// RecordSerial saves a gpci serial the gamemode saw on a connection of the user
+	RecordSerial(ctx context.Context, userID uuid.UUID, serial string) error
+	// GetGraph returns the accounts within 2 hops of the user, with how confident the link is
+	GetGraph(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.AltGraph, error)
+}
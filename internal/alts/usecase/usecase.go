@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/alts"
+	"github.com/iamaul/go-evonix-backend-api/internal/auth"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/clientip"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxPageSize              = 100
+	maxFingerprintLen        = 128
+	maxSerialLen             = 128
+	defaultMaxSharedAccounts = 25
+	// Only the most likely direct links are followed to the second hop
+	maxSecondHopSources = 200
+)
+
+// Alts UseCase
+type altsUC struct {
+	cfg      *config.Config
+	altsRepo alts.Repository
+	authRepo auth.Repository
+	logger   logger.Logger
+}
+
+// NewAltsUseCase Alts UseCase constructor
+func NewAltsUseCase(cfg *config.Config, altsRepo alts.Repository, authRepo auth.Repository, log logger.Logger) alts.UseCase {
+	return &altsUC{cfg: cfg, altsRepo: altsRepo, authRepo: authRepo, logger: log}
+}
+
+// RecordLogin Save the ip and browser fingerprint of a login on the account
+func (u *altsUC) RecordLogin(ctx context.Context, userID uuid.UUID) {
+	ctx, span := otel.Tracer.Start(ctx, "altsUC.RecordLogin")
+	defer span.End()
+
+	meta := utils.GetRequestMetaFromCtx(ctx)
+	if ip := clientip.Normalize(meta.IP); ip != "" {
+		if err := u.altsRepo.SaveIdentifier(ctx, userID, models.IdentifierIP, ip); err != nil {
+			u.logger.Errorf("altsUC.RecordLogin.SaveIdentifier.IP, UserID: %s, Error: %s", userID, err)
+		}
+	}
+	if fingerprint := strings.TrimSpace(meta.Fingerprint); fingerprint != "" && len(fingerprint) <= maxFingerprintLen {
+		if err := u.altsRepo.SaveIdentifier(ctx, userID, models.IdentifierFingerprint, fingerprint); err != nil {
+			u.logger.Errorf("altsUC.RecordLogin.SaveIdentifier.Fingerprint, UserID: %s, Error: %s", userID, err)
+		}
+	}
+}
+
+// RecordSerial Save a gpci serial reported by the gamemode on the account
+func (u *altsUC) RecordSerial(ctx context.Context, userID uuid.UUID, serial string) error {
+	ctx, span := otel.Tracer.Start(ctx, "altsUC.RecordSerial")
+	defer span.End()
+
+	serial = strings.TrimSpace(serial)
+	if serial == "" || len(serial) > maxSerialLen {
+		return httpErr.NewBadRequestError(map[string]string{"serial": "must be between 1 and 128 characters"})
+	}
+	if _, err := u.authRepo.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	return u.altsRepo.SaveIdentifier(ctx, userID, models.IdentifierSerial, serial)
+}
+
+// GetGraph Get the accounts within 2 hops of a user. Every shared identifier weighs the weight of its
+// kind, lowered when more accounts share it, and the weights of a link add up as independent evidence.
+// A 2 hop link is as confident as its two links multiplied, the most confident path wins.
+func (u *altsUC) GetGraph(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.AltGraph, error) {
+	ctx, span := otel.Tracer.Start(ctx, "altsUC.GetGraph")
+	defer span.End()
+
+	if _, err := u.authRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	if pq.GetSize() <= 0 || pq.GetSize() > maxPageSize {
+		pq.Size = maxPageSize
+	}
+	maxShared := u.cfg.Alts.MaxSharedAccounts
+	if maxShared <= 0 {
+		maxShared = defaultMaxSharedAccounts
+	}
+
+	direct, err := u.altsRepo.ListLinks(ctx, []uuid.UUID{userID}, maxShared)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make(map[uuid.UUID]*models.AltAccount)
+	for pair, evidence := range u.groupLinks(direct) {
+		accounts[pair.to] = &models.AltAccount{UserID: pair.to, Confidence: combine(evidence), Hops: 1, Evidence: evidence}
+	}
+
+	sources := sortAccounts(accounts)
+	if len(sources) > maxSecondHopSources {
+		sources = sources[:maxSecondHopSources]
+	}
+	sourceIDs := make([]uuid.UUID, 0, len(sources))
+	// Kept apart so a second hop replacing a direct link never becomes the start of a third
+	directConfidence := make(map[uuid.UUID]float64, len(sources))
+	for _, account := range sources {
+		sourceIDs = append(sourceIDs, account.UserID)
+		directConfidence[account.UserID] = account.Confidence
+	}
+
+	indirect, err := u.altsRepo.ListLinks(ctx, sourceIDs, maxShared)
+	if err != nil {
+		return nil, err
+	}
+	for pair, evidence := range u.groupLinks(indirect) {
+		if pair.to == userID {
+			continue
+		}
+		confidence := directConfidence[pair.from] * combine(evidence)
+		if existing, ok := accounts[pair.to]; ok && existing.Confidence >= confidence {
+			continue
+		}
+		via := pair.from
+		accounts[pair.to] = &models.AltAccount{UserID: pair.to, Confidence: confidence, Hops: 2, Via: &via, Evidence: evidence}
+	}
+
+	for id, account := range accounts {
+		if account.Confidence < u.cfg.Alts.MinConfidence {
+			delete(accounts, id)
+		}
+	}
+	sorted := sortAccounts(accounts)
+
+	totalCount := len(sorted)
+	page := sorted[min(pq.GetOffset(), totalCount):min(pq.GetOffset()+pq.GetLimit(), totalCount)]
+	if err = u.fillUsernames(ctx, page); err != nil {
+		return nil, err
+	}
+
+	return &models.AltGraph{
+		UserID:     userID,
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Accounts:   page,
+	}, nil
+}
+
+type accountPair struct {
+	from uuid.UUID
+	to   uuid.UUID
+}
+
+// groupLinks weighs the shared identifiers and groups them by the pair of accounts they link
+func (u *altsUC) groupLinks(links []*models.AccountLink) map[accountPair][]models.AltEvidence {
+	pairs := make(map[accountPair][]models.AltEvidence)
+	for _, link := range links {
+		weight := u.kindWeight(link.Kind)
+		if link.SharedCount > 2 {
+			weight *= 2 / float64(link.SharedCount)
+		}
+		pair := accountPair{from: link.UserID, to: link.LinkedUserID}
+		pairs[pair] = append(pairs[pair], models.AltEvidence{
+			Kind:        link.Kind,
+			Value:       link.Value,
+			SharedCount: link.SharedCount,
+			Weight:      round(weight),
+		})
+	}
+	return pairs
+}
+
+func (u *altsUC) kindWeight(kind string) float64 {
+	switch kind {
+	case models.IdentifierSerial:
+		return u.cfg.Alts.SerialWeight
+	case models.IdentifierFingerprint:
+		return u.cfg.Alts.FingerprintWeight
+	default:
+		return u.cfg.Alts.IPWeight
+	}
+}
+
+func (u *altsUC) fillUsernames(ctx context.Context, accounts []*models.AltAccount) error {
+	ids := make([]uuid.UUID, 0, len(accounts))
+	for _, account := range accounts {
+		ids = append(ids, account.UserID)
+	}
+	usernames, err := u.altsRepo.GetUsernames(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		account.Username = usernames[account.UserID]
+		account.Confidence = round(account.Confidence)
+	}
+	return nil
+}
+
+// combine adds up independent evidence, the link is only missed if every piece of evidence is wrong
+func combine(evidence []models.AltEvidence) float64 {
+	missed := 1.0
+	for _, e := range evidence {
+		missed *= 1 - math.Min(math.Max(e.Weight, 0), 1)
+	}
+	return 1 - missed
+}
+
+// sortAccounts orders accounts by confidence, then direct links first
+func sortAccounts(accounts map[uuid.UUID]*models.AltAccount) []*models.AltAccount {
+	sorted := make([]*models.AltAccount, 0, len(accounts))
+	for _, account := range accounts {
+		sorted = append(sorted, account)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Confidence != sorted[j].Confidence {
+			return sorted[i].Confidence > sorted[j].Confidence
+		}
+		if sorted[i].Hops != sorted[j].Hops {
+			return sorted[i].Hops < sorted[j].Hops
+		}
+		return sorted[i].UserID.String() < sorted[j].UserID.String()
+	})
+	return sorted
+}
+
+func round(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
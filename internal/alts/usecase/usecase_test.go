@@ -0,0 +1,347 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/auth"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type identifier struct {
+	kind  string
+	value string
+}
+
+// memoryAltsRepo keeps the identifiers of every account and links them like the listLinks query
+type memoryAltsRepo struct {
+	identifiers map[uuid.UUID][]identifier
+}
+
+func (r *memoryAltsRepo) SaveIdentifier(_ context.Context, userID uuid.UUID, kind, value string) error {
+	for _, id := range r.identifiers[userID] {
+		if id.kind == kind && id.value == value {
+			return nil
+		}
+	}
+	r.identifiers[userID] = append(r.identifiers[userID], identifier{kind: kind, value: value})
+	return nil
+}
+
+func (r *memoryAltsRepo) ListLinks(_ context.Context, userIDs []uuid.UUID, maxShared int) ([]*models.AccountLink, error) {
+	holders := make(map[identifier][]uuid.UUID)
+	for userID, ids := range r.identifiers {
+		for _, id := range ids {
+			holders[id] = append(holders[id], userID)
+		}
+	}
+
+	links := make([]*models.AccountLink, 0)
+	for _, userID := range userIDs {
+		for _, id := range r.identifiers[userID] {
+			shared := len(holders[id])
+			if shared < 2 || shared > maxShared {
+				continue
+			}
+			for _, linked := range holders[id] {
+				if linked != userID {
+					links = append(links, &models.AccountLink{
+						UserID: userID, LinkedUserID: linked, Kind: id.kind, Value: id.value, SharedCount: shared,
+					})
+				}
+			}
+		}
+	}
+	return links, nil
+}
+
+func (r *memoryAltsRepo) GetUsernames(_ context.Context, userIDs []uuid.UUID) (map[uuid.UUID]string, error) {
+	usernames := make(map[uuid.UUID]string, len(userIDs))
+	for _, userID := range userIDs {
+		usernames[userID] = name(userID)
+	}
+	return usernames, nil
+}
+
+// memoryAuthRepo only knows the users it was given
+type memoryAuthRepo struct {
+	auth.Repository
+	users map[uuid.UUID]bool
+}
+
+func (r *memoryAuthRepo) GetByID(_ context.Context, userID uuid.UUID) (*models.User, error) {
+	if !r.users[userID] {
+		return nil, sql.ErrNoRows
+	}
+	return &models.User{ID: userID, Username: name(userID)}, nil
+}
+
+// Accounts sort by id when their confidence ties
+var (
+	target = uuid.MustParse("00000000-0000-0000-0000-000000000000")
+	userA  = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	userB  = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	userC  = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	userD  = uuid.MustParse("00000000-0000-0000-0000-00000000000d")
+	userE  = uuid.MustParse("00000000-0000-0000-0000-00000000000e")
+	userF  = uuid.MustParse("00000000-0000-0000-0000-00000000000f")
+)
+
+func name(userID uuid.UUID) string {
+	return fmt.Sprintf("user_%s", userID.String()[35:])
+}
+
+func newTestAltsUC(identifiers map[uuid.UUID][]identifier) (*altsUC, *memoryAltsRepo) {
+	cfg := &config.Config{}
+	cfg.Alts = config.Alts{
+		IPWeight:          0.4,
+		SerialWeight:      0.9,
+		FingerprintWeight: 0.7,
+		MaxSharedAccounts: 3,
+		MinConfidence:     0.1,
+	}
+
+	users := map[uuid.UUID]bool{target: true}
+	for userID := range identifiers {
+		users[userID] = true
+	}
+	altsRepo := &memoryAltsRepo{identifiers: identifiers}
+	return &altsUC{cfg: cfg, altsRepo: altsRepo, authRepo: &memoryAuthRepo{users: users}}, altsRepo
+}
+
+func ip(value string) identifier {
+	return identifier{kind: models.IdentifierIP, value: value}
+}
+
+func serial(value string) identifier {
+	return identifier{kind: models.IdentifierSerial, value: value}
+}
+
+func fingerprint(value string) identifier {
+	return identifier{kind: models.IdentifierFingerprint, value: value}
+}
+
+type wantAccount struct {
+	userID     uuid.UUID
+	confidence float64
+	hops       int
+	via        uuid.UUID
+}
+
+func TestGetGraph(t *testing.T) {
+	tests := []struct {
+		name        string
+		identifiers map[uuid.UUID][]identifier
+		want        []wantAccount
+	}{
+		{
+			name: "identifiers of a link add up",
+			identifiers: map[uuid.UUID][]identifier{
+				target: {serial("S1"), ip("10.0.0.1")},
+				userA:  {serial("S1"), ip("10.0.0.1")},
+			},
+			// 1 - (1 - 0.9) * (1 - 0.4)
+			want: []wantAccount{{userA, 0.94, 1, uuid.Nil}},
+		},
+		{
+			name: "identifiers shared by more accounts weigh less",
+			identifiers: map[uuid.UUID][]identifier{
+				target: {ip("10.0.0.1")},
+				userA:  {ip("10.0.0.1")},
+				userB:  {ip("10.0.0.1")},
+			},
+			// 0.4 * 2 / 3, the link between A and B on the second hop is weaker than the direct ones
+			want: []wantAccount{{userA, 0.267, 1, uuid.Nil}, {userB, 0.267, 1, uuid.Nil}},
+		},
+		{
+			name: "identifiers shared by too many accounts are ignored",
+			identifiers: map[uuid.UUID][]identifier{
+				target: {ip("10.0.0.1"), serial("S1")},
+				userA:  {ip("10.0.0.1"), serial("S1")},
+				userB:  {ip("10.0.0.1")},
+				userC:  {ip("10.0.0.1")},
+			},
+			want: []wantAccount{{userA, 0.9, 1, uuid.Nil}},
+		},
+		{
+			name: "a second hop multiplies its links",
+			identifiers: map[uuid.UUID][]identifier{
+				target: {serial("S1")},
+				userA:  {serial("S1"), fingerprint("F1")},
+				userB:  {fingerprint("F1")},
+			},
+			want: []wantAccount{{userA, 0.9, 1, uuid.Nil}, {userB, 0.63, 2, userA}},
+		},
+		{
+			name: "a stronger second hop replaces a direct link",
+			identifiers: map[uuid.UUID][]identifier{
+				target: {serial("S1"), ip("10.0.0.1")},
+				userA:  {serial("S1"), serial("S2")},
+				userB:  {serial("S2"), ip("10.0.0.1")},
+			},
+			// 0.9 * 0.9 through A beats the 0.4 of the shared ip
+			want: []wantAccount{{userA, 0.9, 1, uuid.Nil}, {userB, 0.81, 2, userA}},
+		},
+		{
+			name: "a direct link beats a weaker second hop",
+			identifiers: map[uuid.UUID][]identifier{
+				target: {serial("S1"), fingerprint("F1")},
+				userA:  {serial("S1"), ip("10.0.0.1")},
+				userB:  {ip("10.0.0.1"), fingerprint("F1")},
+			},
+			want: []wantAccount{{userA, 0.9, 1, uuid.Nil}, {userB, 0.7, 1, uuid.Nil}},
+		},
+		{
+			name: "weak links and a third hop are left out",
+			identifiers: map[uuid.UUID][]identifier{
+				target: {ip("10.0.0.1")},
+				userA:  {ip("10.0.0.1"), ip("10.0.0.2")},
+				userE:  {ip("10.0.0.1")},
+				// 0.267 * 0.267 is under the minimum confidence
+				userB: {ip("10.0.0.2"), serial("S1")},
+				userC: {ip("10.0.0.2")},
+				// Three hops away
+				userD: {serial("S1")},
+			},
+			want: []wantAccount{{userA, 0.267, 1, uuid.Nil}, {userE, 0.267, 1, uuid.Nil}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, _ := newTestAltsUC(tt.identifiers)
+
+			graph, err := u.GetGraph(context.Background(), target, &utils.PaginationQuery{Size: 10, Page: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if graph.TotalCount != len(tt.want) || len(graph.Accounts) != len(tt.want) {
+				t.Fatalf("GetGraph() returned %d of %d accounts, want %d", len(graph.Accounts), graph.TotalCount, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := graph.Accounts[i]
+				if got.UserID != want.userID || got.Confidence != want.confidence || got.Hops != want.hops {
+					t.Errorf("account %d = %s %v %d hops, want %s %v %d hops",
+						i, got.UserID, got.Confidence, got.Hops, want.userID, want.confidence, want.hops)
+				}
+				if (got.Via == nil) != (want.via == uuid.Nil) || got.Via != nil && *got.Via != want.via {
+					t.Errorf("account %d is linked via %v, want %s", i, got.Via, want.via)
+				}
+				if got.Username != name(want.userID) {
+					t.Errorf("account %d username = %q, want %q", i, got.Username, name(want.userID))
+				}
+			}
+		})
+	}
+}
+
+func TestGetGraphPagination(t *testing.T) {
+	u, _ := newTestAltsUC(map[uuid.UUID][]identifier{
+		target: {serial("S1"), serial("S2"), serial("S3"), fingerprint("F1"), fingerprint("F2"), ip("10.0.0.1"), ip("10.0.0.2")},
+		userA:  {serial("S1"), fingerprint("F1")},
+		userB:  {serial("S2")},
+		userC:  {fingerprint("F2")},
+		userD:  {ip("10.0.0.1")},
+		userE:  {serial("S3"), ip("10.0.0.2")},
+	})
+	// Ordered by confidence: A 0.97, E 0.94, B 0.9, C 0.7, D 0.4
+
+	tests := []struct {
+		name           string
+		pq             *utils.PaginationQuery
+		want           []uuid.UUID
+		wantSize       int
+		wantTotalPages int
+		wantHasMore    bool
+	}{
+		{"first page", &utils.PaginationQuery{Size: 2, Page: 1}, []uuid.UUID{userA, userE}, 2, 3, true},
+		{"middle page", &utils.PaginationQuery{Size: 2, Page: 2}, []uuid.UUID{userB, userC}, 2, 3, true},
+		{"last page", &utils.PaginationQuery{Size: 2, Page: 3}, []uuid.UUID{userD}, 2, 3, false},
+		{"past the last page", &utils.PaginationQuery{Size: 2, Page: 4}, []uuid.UUID{}, 2, 3, false},
+		{"no size", &utils.PaginationQuery{Page: 1}, []uuid.UUID{userA, userE, userB, userC, userD}, maxPageSize, 1, false},
+		{"size over the maximum", &utils.PaginationQuery{Size: 500, Page: 1}, []uuid.UUID{userA, userE, userB, userC, userD}, maxPageSize, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, err := u.GetGraph(context.Background(), target, tt.pq)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if graph.TotalCount != 5 || graph.Size != tt.wantSize || graph.TotalPages != tt.wantTotalPages || graph.HasMore != tt.wantHasMore {
+				t.Fatalf("GetGraph() total %d, size %d, %d pages, has more %v, want 5, %d, %d, %v",
+					graph.TotalCount, graph.Size, graph.TotalPages, graph.HasMore, tt.wantSize, tt.wantTotalPages, tt.wantHasMore)
+			}
+			if len(graph.Accounts) != len(tt.want) {
+				t.Fatalf("GetGraph() returned %d accounts, want %d", len(graph.Accounts), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if graph.Accounts[i].UserID != want {
+					t.Errorf("account %d = %s, want %s", i, graph.Accounts[i].UserID, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetGraphUnknownUser(t *testing.T) {
+	u, _ := newTestAltsUC(map[uuid.UUID][]identifier{})
+
+	if _, err := u.GetGraph(context.Background(), userF, &utils.PaginationQuery{Size: 10, Page: 1}); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("GetGraph() error = %v, want %v", err, sql.ErrNoRows)
+	}
+}
+
+func TestRecordSerial(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     uuid.UUID
+		serial     string
+		wantStatus int
+		wantSaved  []identifier
+	}{
+		{"serial is saved", target, "ED4C8C5D8E0A1D9ADC8E4489D9E4A8DE9D8C9F4C", 0, []identifier{serial("ED4C8C5D8E0A1D9ADC8E4489D9E4A8DE9D8C9F4C")}},
+		{"surrounding spaces are trimmed", target, "  S1 ", 0, []identifier{serial("S1")}},
+		{"empty serial", target, "   ", http.StatusBadRequest, nil},
+		{"serial longer than the column", target, string(make([]byte, maxSerialLen+1)), http.StatusBadRequest, nil},
+		{"unknown user", userF, "S1", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, altsRepo := newTestAltsUC(map[uuid.UUID][]identifier{})
+
+			err := u.RecordSerial(context.Background(), tt.userID, tt.serial)
+			if tt.wantStatus == 0 && err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantStatus != 0 {
+				if err == nil {
+					t.Fatalf("RecordSerial() error = nil, want status %d", tt.wantStatus)
+				}
+				if status := httpErr.ParseErrors(err).Status(); status != tt.wantStatus {
+					t.Fatalf("RecordSerial() error = %v with status %d, want status %d", err, status, tt.wantStatus)
+				}
+			}
+
+			saved := altsRepo.identifiers[tt.userID]
+			if len(saved) != len(tt.wantSaved) {
+				t.Fatalf("saved %v, want %v", saved, tt.wantSaved)
+			}
+			for i := range saved {
+				if saved[i] != tt.wantSaved[i] {
+					t.Fatalf("saved %v, want %v", saved, tt.wantSaved)
+				}
+			}
+		})
+	}
+}
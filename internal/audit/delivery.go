@@ -0,0 +1,9 @@
+package audit
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Audit log HTTP Handlers interface
+type Handlers interface {
+	ListMine() echo.HandlerFunc
+	List() echo.HandlerFunc
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/audit"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/clientip"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Audit log handlers
+type auditHandlers struct {
+	cfg     *config.Config
+	auditUC audit.UseCase
+	logger  logger.Logger
+}
+
+// NewAuditHandlers Audit log handlers constructor
+func NewAuditHandlers(cfg *config.Config, auditUC audit.UseCase, log logger.Logger) audit.Handlers {
+	return &auditHandlers{cfg: cfg, auditUC: auditUC, logger: log}
+}
+
+// ListMine Get the security history of the current user
+func (h *auditHandlers) ListMine() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "auditHandlers.ListMine")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErr.NewBadRequestError(httpErr.ErrBadQueryParams.Error()))
+		}
+
+		logs, err := h.auditUC.List(ctx, &models.AuditLogFilter{Action: c.QueryParam("action"), TargetID: &principal.UserID}, pq)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: logs, Success: true})
+	}
+}
+
+// List Get the audit log filtered by action, actor_id, target_id, ip and a from/to time range, for staff
+func (h *auditHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "auditHandlers.List")
+		defer span.End()
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErr.NewBadRequestError(httpErr.ErrBadQueryParams.Error()))
+		}
+		filter, err := filterFromQuery(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		logs, err := h.auditUC.List(ctx, filter, pq)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: logs, Success: true})
+	}
+}
+
+func filterFromQuery(c echo.Context) (*models.AuditLogFilter, error) {
+	filter := &models.AuditLogFilter{Action: c.QueryParam("action")}
+
+	if value := c.QueryParam("ip"); value != "" {
+		if filter.IP = clientip.Normalize(value); filter.IP == "" {
+			return nil, httpErr.NewBadRequestError(map[string]string{"ip": "must be an IP address"})
+		}
+	}
+
+	for param, dst := range map[string]**uuid.UUID{"actor_id": &filter.ActorID, "target_id": &filter.TargetID} {
+		if value := c.QueryParam(param); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return nil, httpErr.NewBadRequestError(map[string]string{param: "must be a UUID"})
+			}
+			*dst = &id
+		}
+	}
+	for param, dst := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := c.QueryParam(param); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, httpErr.NewBadRequestError(map[string]string{param: "must be an RFC 3339 time"})
+			}
+			*dst = &t
+		}
+	}
+
+	return filter, nil
+}
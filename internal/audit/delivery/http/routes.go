@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/internal/audit"
+	"github.com/iamaul/go-evonix-backend-api/internal/middleware"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MapAuditRoutes Map the security history route of the account settings
+func MapAuditRoutes(accountGroup *echo.Group, h audit.Handlers, mw *middleware.MiddlewareManager) {
+	accountGroup.GET("/audit", h.ListMine(), mw.AuthMiddleware)
+}
+
+// MapAuditAdminRoutes Map the audit log route of the staff tools
+func MapAuditAdminRoutes(adminGroup *echo.Group, h audit.Handlers, mw *middleware.MiddlewareManager) {
+	adminGroup.GET("/audit", h.List(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionAuditRead))
+}
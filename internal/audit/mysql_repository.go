@@ -0,0 +1,15 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+)
+
+// Repository Audit log MySQL repository interface, the table is append-only
+type Repository interface {
+	Create(ctx context.Context, log *models.AuditLog) error
+	// List returns a page of matching entries, newest first, and the total number of matches
+	List(ctx context.Context, filter *models.AuditLogFilter, pq *utils.PaginationQuery) ([]*models.AuditLog, int, error)
+}
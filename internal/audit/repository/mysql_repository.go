@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/audit"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Audit log Repository
+type auditRepo struct {
+	db *sqlx.DB
+}
+
+// NewAuditRepository Audit log Repository constructor
+func NewAuditRepository(db *sqlx.DB) audit.Repository {
+	return &auditRepo{db: db}
+}
+
+// Create Append an audit log entry
+func (r *auditRepo) Create(ctx context.Context, log *models.AuditLog) error {
+	ctx, span := otel.Tracer.Start(ctx, "auditRepo.Create")
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, createAuditLog,
+		log.Action,
+		log.ActorID,
+		log.TargetID,
+		log.IP,
+		log.UserAgent,
+		log.RequestID,
+		log.Changes,
+	); err != nil {
+		return errors.Wrap(err, "auditRepo.Create.ExecContext")
+	}
+
+	return nil
+}
+
+// List Get a page of audit log entries
+func (r *auditRepo) List(ctx context.Context, filter *models.AuditLogFilter, pq *utils.PaginationQuery) ([]*models.AuditLog, int, error) {
+	ctx, span := otel.Tracer.Start(ctx, "auditRepo.List")
+	defer span.End()
+
+	where, args := auditLogConditions(filter)
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, countAuditLogs+where, args...); err != nil {
+		return nil, 0, errors.Wrap(err, "auditRepo.List.GetContext")
+	}
+
+	logs := make([]*models.AuditLog, 0, pq.GetLimit())
+	if totalCount == 0 {
+		return logs, 0, nil
+	}
+
+	query := listAuditLogs + where + ` ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+	if err := r.db.SelectContext(ctx, &logs, query, append(args, pq.GetLimit(), pq.GetOffset())...); err != nil {
+		return nil, 0, errors.Wrap(err, "auditRepo.List.SelectContext")
+	}
+
+	return logs, totalCount, nil
+}
+
+func auditLogConditions(filter *models.AuditLogFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.ActorID != nil {
+		conditions = append(conditions, "actor_id = ?")
+		args = append(args, filter.ActorID)
+	}
+	if filter.TargetID != nil {
+		conditions = append(conditions, "target_id = ?")
+		args = append(args, filter.TargetID)
+	}
+	if filter.IP != "" {
+		conditions = append(conditions, "ip = ?")
+		args = append(args, filter.IP)
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.To)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,12 @@
+package repository
+
+const (
+	createAuditLog = `INSERT INTO audit_logs (action, actor_id, target_id, ip, user_agent, request_id, changes)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	// The filters are appended by List
+	listAuditLogs = `SELECT id, action, actor_id, target_id, ip, user_agent, request_id, changes, created_at
+		FROM audit_logs`
+
+	countAuditLogs = `SELECT COUNT(*) FROM audit_logs`
+)
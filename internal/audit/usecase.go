@@ -0,0 +1,16 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+)
+
+// UseCase Audit log UseCase interface
+type UseCase interface {
+	// Record appends an entry with the meta of the request in ctx, the actor defaults to the
+	// principal of the request. Failures are logged, they never fail the audited action.
+	Record(ctx context.Context, log *models.AuditLog)
+	List(ctx context.Context, filter *models.AuditLogFilter, pq *utils.PaginationQuery) (*models.AuditLogList, error)
+}
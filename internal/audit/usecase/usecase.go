@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/audit"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+)
+
+const (
+	maxPageSize     = 100
+	maxUserAgentLen = 512
+)
+
+// Audit log UseCase
+type auditUC struct {
+	cfg       *config.Config
+	auditRepo audit.Repository
+	logger    logger.Logger
+}
+
+// NewAuditUseCase Audit log UseCase constructor
+func NewAuditUseCase(cfg *config.Config, auditRepo audit.Repository, log logger.Logger) audit.UseCase {
+	return &auditUC{cfg: cfg, auditRepo: auditRepo, logger: log}
+}
+
+// Record Append an audit log entry
+func (u *auditUC) Record(ctx context.Context, log *models.AuditLog) {
+	ctx, span := otel.Tracer.Start(ctx, "auditUC.Record")
+	defer span.End()
+
+	meta := utils.GetRequestMetaFromCtx(ctx)
+	log.IP, log.UserAgent, log.RequestID = meta.IP, meta.UserAgent, meta.RequestID
+	if len(log.UserAgent) > maxUserAgentLen {
+		log.UserAgent = log.UserAgent[:maxUserAgentLen]
+	}
+	if log.ActorID == nil {
+		if principal, err := utils.GetPrincipalFromCtx(ctx); err == nil {
+			log.ActorID = &principal.UserID
+		}
+	}
+
+	if err := u.auditRepo.Create(ctx, log); err != nil {
+		u.logger.Errorf("auditUC.Record.Create, Action: %s, RequestID: %s, Error: %s", log.Action, log.RequestID, err)
+	}
+}
+
+// List Get a page of audit log entries
+func (u *auditUC) List(ctx context.Context, filter *models.AuditLogFilter, pq *utils.PaginationQuery) (*models.AuditLogList, error) {
+	ctx, span := otel.Tracer.Start(ctx, "auditUC.List")
+	defer span.End()
+
+	if pq.GetSize() <= 0 || pq.GetSize() > maxPageSize {
+		pq.Size = maxPageSize
+	}
+
+	logs, totalCount, err := u.auditRepo.List(ctx, filter, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuditLogList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Logs:       logs,
+	}, nil
+}
@@ -0,0 +1,19 @@
+package auth
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Auth HTTP Handlers interface
+type Handlers interface {
+	Register() echo.HandlerFunc
+	Login() echo.HandlerFunc
+	LoginTwoFactor() echo.HandlerFunc
+	Refresh() echo.HandlerFunc
+	Logout() echo.HandlerFunc
+	ForgotPassword() echo.HandlerFunc
+	ResetPassword() echo.HandlerFunc
+	VerifyEmail() echo.HandlerFunc
+	ResendEmailVerification() echo.HandlerFunc
+	ChangeEmail() echo.HandlerFunc
+	Me() echo.HandlerFunc
+	JWKS() echo.HandlerFunc
+}
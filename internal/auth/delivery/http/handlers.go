@@ -0,0 +1,281 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/auth"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/jwt"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+const jwksMaxAge = "public, max-age=300"
+
+// Auth handlers
+type authHandlers struct {
+	cfg          *config.Config
+	authUC       auth.UseCase
+	tokenManager jwt.TokenManager
+	logger       logger.Logger
+}
+
+// NewAuthHandlers Auth handlers constructor
+func NewAuthHandlers(cfg *config.Config, authUC auth.UseCase, tokenManager jwt.TokenManager, log logger.Logger) auth.Handlers {
+	return &authHandlers{cfg: cfg, authUC: authUC, tokenManager: tokenManager, logger: log}
+}
+
+// Register Create an account, it can log in right away but some features wait for the email to be verified
+func (h *authHandlers) Register() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "authHandlers.Register")
+		defer span.End()
+
+		input := &models.RegisterRequest{}
+		if err := utils.SanitizeRequest(c, input, "password"); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		user, err := h.authUC.Register(ctx, input)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusCreated, utils.ResponseJSON{
+			Code:    http.StatusCreated,
+			Message: "Confirm your email with the link sent to it",
+			Result:  user,
+			Success: true,
+		})
+	}
+}
+
+// Login First login step, answers with tokens or a two-factor challenge
+func (h *authHandlers) Login() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "authHandlers.Login")
+		defer span.End()
+
+		input := &models.LoginRequest{}
+		if err := utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		input.IP, input.UserAgent = utils.GetIPAddress(c), c.Request().UserAgent()
+
+		result, err := h.authUC.Login(ctx, input)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return h.loginResponse(c, result)
+	}
+}
+
+// LoginTwoFactor Second login step
+func (h *authHandlers) LoginTwoFactor() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "authHandlers.LoginTwoFactor")
+		defer span.End()
+
+		input := &models.LoginTwoFactorRequest{}
+		if err := utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		input.IP, input.UserAgent = utils.GetIPAddress(c), c.Request().UserAgent()
+
+		result, err := h.authUC.LoginTwoFactor(ctx, input)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return h.loginResponse(c, result)
+	}
+}
+
+// Refresh Rotate the refresh token
+func (h *authHandlers) Refresh() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "authHandlers.Refresh")
+		defer span.End()
+
+		input := &models.RefreshRequest{}
+		if err := utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		tokens, err := h.authUC.Refresh(ctx, input.RefreshToken)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		c.SetCookie(utils.ConfigureJWTCookie(h.cfg, tokens.AccessToken))
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: tokens, Success: true})
+	}
+}
+
+// Logout Revoke the current session and drop the cookie
+func (h *authHandlers) Logout() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "authHandlers.Logout")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.authUC.Logout(ctx, principal); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		utils.DeleteSessionCookie(c, h.cfg.Cookie.Name)
+		utils.DeleteSessionCookie(c, h.cfg.Session.Name)
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// ForgotPassword Mail a password reset link, the answer never tells whether the email exists
+func (h *authHandlers) ForgotPassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "authHandlers.ForgotPassword")
+		defer span.End()
+
+		input := &models.ForgotPasswordRequest{}
+		if err := utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err := h.authUC.ForgotPassword(ctx, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusAccepted, utils.ResponseJSON{
+			Code:    http.StatusAccepted,
+			Message: "If an account uses this email, a password reset link is on its way",
+			Success: true,
+		})
+	}
+}
+
+// ResetPassword Set a new password with the token from the email
+func (h *authHandlers) ResetPassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "authHandlers.ResetPassword")
+		defer span.End()
+
+		input := &models.ResetPasswordRequest{}
+		if err := utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err := h.authUC.ResetPassword(ctx, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		utils.DeleteSessionCookie(c, h.cfg.Cookie.Name)
+		utils.DeleteSessionCookie(c, h.cfg.Session.Name)
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// VerifyEmail Verify an email with the token from the verification email
+func (h *authHandlers) VerifyEmail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "authHandlers.VerifyEmail")
+		defer span.End()
+
+		input := &models.VerifyEmailRequest{}
+		if err := utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err := h.authUC.VerifyEmail(ctx, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// ResendEmailVerification Mail a new verification link to the current user
+func (h *authHandlers) ResendEmailVerification() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "authHandlers.ResendEmailVerification")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.authUC.ResendEmailVerification(ctx, principal.UserID); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusAccepted, utils.ResponseJSON{
+			Code:    http.StatusAccepted,
+			Message: "A verification link is on its way",
+			Success: true,
+		})
+	}
+}
+
+// ChangeEmail Start an email change of the current user
+func (h *authHandlers) ChangeEmail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "authHandlers.ChangeEmail")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		input := &models.ChangeEmailRequest{}
+		if err = utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.authUC.ChangeEmail(ctx, principal.UserID, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusAccepted, utils.ResponseJSON{
+			Code:    http.StatusAccepted,
+			Message: "Confirm the new email with the link sent to it, the current email stays active until then",
+			Success: true,
+		})
+	}
+}
+
+// Me returns the authenticated principal, personal access tokens need the profile:read scope
+func (h *authHandlers) Me() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		principal, err := utils.GetPrincipalFromCtx(c.Request().Context())
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: principal, Success: true})
+	}
+}
+
+// JWKS publishes the public keys that verify UCP tokens, for the Discord bot, forum and other consumers
+func (h *authHandlers) JWKS() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Response().Header().Set("Cache-Control", jwksMaxAge)
+		return c.JSON(http.StatusOK, h.tokenManager.JWKS())
+	}
+}
+
+func (h *authHandlers) loginResponse(c echo.Context, result *models.LoginResult) error {
+	if result.Tokens != nil {
+		c.SetCookie(utils.ConfigureJWTCookie(h.cfg, result.Tokens.AccessToken))
+		c.SetCookie(utils.CreateSessionCookie(h.cfg, result.SessionID))
+	}
+	return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: result, Success: true})
+}
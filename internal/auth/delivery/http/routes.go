@@ -0,0 +1,33 @@
+package http
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/internal/auth"
+	"github.com/iamaul/go-evonix-backend-api/internal/middleware"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MapAuthRoutes Map auth routes
+func MapAuthRoutes(authGroup *echo.Group, h auth.Handlers, mw *middleware.MiddlewareManager) {
+	authGroup.POST("/register", h.Register(), mw.RateLimitMiddleware("registration"))
+	authGroup.POST("/login", h.Login(), mw.RateLimitMiddleware("login"))
+	authGroup.POST("/login/2fa", h.LoginTwoFactor(), mw.RateLimitMiddleware("login"))
+	authGroup.POST("/refresh", h.Refresh())
+	authGroup.POST("/logout", h.Logout(), mw.AuthMiddleware)
+	authGroup.POST("/password/forgot", h.ForgotPassword(), mw.RateLimitMiddleware("password-reset"))
+	authGroup.POST("/password/reset", h.ResetPassword())
+	authGroup.POST("/email/verify", h.VerifyEmail())
+	authGroup.GET("/me", h.Me(), mw.ScopedAuthMiddleware(models.ScopeProfileRead))
+}
+
+// MapAccountRoutes Map the account settings routes of the auth domain, they work for unverified accounts too
+func MapAccountRoutes(accountGroup *echo.Group, h auth.Handlers, mw *middleware.MiddlewareManager) {
+	accountGroup.POST("/email/resend", h.ResendEmailVerification(), mw.AuthMiddleware, mw.RateLimitMiddleware("email-verification"))
+	accountGroup.PUT("/email", h.ChangeEmail(), mw.AuthMiddleware, mw.RateLimitMiddleware("email-verification"))
+}
+
+// MapWellKnownRoutes Map the public discovery routes served from the root
+func MapWellKnownRoutes(wellKnownGroup *echo.Group, h auth.Handlers) {
+	wellKnownGroup.GET("/jwks.json", h.JWKS())
+}
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Repository Auth MySQL repository interface
+type Repository interface {
+	Create(ctx context.Context, user *models.User) (*models.User, error)
+	GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	// GetByLogin finds a user by username or email, both compared case-insensitively
+	GetByLogin(ctx context.Context, login string) (*models.User, error)
+	// GetByUsername finds a user by username, compared case-insensitively
+	GetByUsername(ctx context.Context, username string) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	// MarkEmailVerified verifies the email if it still is the current one
+	MarkEmailVerified(ctx context.Context, userID uuid.UUID, email string) error
+	SetPendingEmail(ctx context.Context, userID uuid.UUID, email string) error
+	// UpdateAdminLevel sets the in-game admin level the gamemode reads
+	UpdateAdminLevel(ctx context.Context, userID uuid.UUID, adminLevel int) error
+	// ConfirmPendingEmail swaps in the pending email if it still is the pending one
+	ConfirmPendingEmail(ctx context.Context, userID uuid.UUID, email string) error
+}
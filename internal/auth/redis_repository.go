@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RedisRepository Auth Redis repository interface
+type RedisRepository interface {
+	SaveLoginChallenge(ctx context.Context, challengeToken string, userID uuid.UUID, expire time.Duration) error
+	GetLoginChallenge(ctx context.Context, challengeToken string) (uuid.UUID, error)
+	// IncrLoginChallengeAttempts counts failed second step attempts and returns the new count
+	IncrLoginChallengeAttempts(ctx context.Context, challengeToken string, expire time.Duration) (int64, error)
+	DeleteLoginChallenge(ctx context.Context, challengeToken string) error
+	// SavePasswordResetToken stores the token hash and invalidates the previous token of the user
+	SavePasswordResetToken(ctx context.Context, tokenHash string, userID uuid.UUID, expire time.Duration) error
+	// GetPasswordResetToken returns the user of the token without using it up
+	GetPasswordResetToken(ctx context.Context, tokenHash string) (uuid.UUID, error)
+	// ConsumePasswordResetToken returns the user of the token and deletes it in the same step
+	ConsumePasswordResetToken(ctx context.Context, tokenHash string) (uuid.UUID, error)
+	// SaveEmailVerification stores the token hash for the address it verifies and invalidates the previous token of the user
+	SaveEmailVerification(ctx context.Context, tokenHash string, userID uuid.UUID, email string, expire time.Duration) error
+	// ConsumeEmailVerification returns the user and address of the token and deletes it in the same step
+	ConsumeEmailVerification(ctx context.Context, tokenHash string) (uuid.UUID, string, error)
+	// AcquireEmailVerificationCooldown reports false while the user is still in the resend cooldown
+	AcquireEmailVerificationCooldown(ctx context.Context, userID uuid.UUID, cooldown time.Duration) (bool, error)
+	// EmailVerificationCooldownTTL returns how long the resend cooldown of the user still runs
+	EmailVerificationCooldownTTL(ctx context.Context, userID uuid.UUID) (time.Duration, error)
+}
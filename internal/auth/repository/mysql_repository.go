@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/auth"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Auth Repository
+type authRepo struct {
+	db *sqlx.DB
+}
+
+// NewAuthRepository Auth Repository constructor
+func NewAuthRepository(db *sqlx.DB) auth.Repository {
+	return &authRepo{db: db}
+}
+
+// Create Insert a new user, the unique keys reject a taken username or email
+func (r *authRepo) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authRepo.Create")
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, createUser, user.ID, user.Username, user.Email, user.Password); err != nil {
+		return nil, errors.Wrap(err, "authRepo.Create.ExecContext")
+	}
+
+	return r.GetByID(ctx, user.ID)
+}
+
+// GetByID Get user by id
+func (r *authRepo) GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authRepo.GetByID")
+	defer span.End()
+
+	user := &models.User{}
+	if err := r.db.GetContext(ctx, user, getUserByID, userID); err != nil {
+		return nil, errors.Wrap(err, "authRepo.GetByID.GetContext")
+	}
+
+	return user, nil
+}
+
+// GetByLogin Get user by username or email, the utf8mb4 collation compares case-insensitively
+func (r *authRepo) GetByLogin(ctx context.Context, login string) (*models.User, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authRepo.GetByLogin")
+	defer span.End()
+
+	user := &models.User{}
+	if err := r.db.GetContext(ctx, user, getUserByLogin, login, login); err != nil {
+		return nil, errors.Wrap(err, "authRepo.GetByLogin.GetContext")
+	}
+
+	return user, nil
+}
+
+// GetByUsername Get user by username, the utf8mb4 collation compares case-insensitively
+func (r *authRepo) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authRepo.GetByUsername")
+	defer span.End()
+
+	user := &models.User{}
+	if err := r.db.GetContext(ctx, user, getUserByUsername, username); err != nil {
+		return nil, errors.Wrap(err, "authRepo.GetByUsername.GetContext")
+	}
+
+	return user, nil
+}
+
+// GetByEmail Get user by email
+func (r *authRepo) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authRepo.GetByEmail")
+	defer span.End()
+
+	user := &models.User{}
+	if err := r.db.GetContext(ctx, user, getUserByEmail, email); err != nil {
+		return nil, errors.Wrap(err, "authRepo.GetByEmail.GetContext")
+	}
+
+	return user, nil
+}
+
+// UpdatePassword Update the password hash of a user
+func (r *authRepo) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	ctx, span := otel.Tracer.Start(ctx, "authRepo.UpdatePassword")
+	defer span.End()
+
+	return r.execAffectingOne(ctx, "authRepo.UpdatePassword", updateUserPassword, passwordHash, userID)
+}
+
+// MarkEmailVerified Verify the current email of a user
+func (r *authRepo) MarkEmailVerified(ctx context.Context, userID uuid.UUID, email string) error {
+	ctx, span := otel.Tracer.Start(ctx, "authRepo.MarkEmailVerified")
+	defer span.End()
+
+	return r.execAffectingOne(ctx, "authRepo.MarkEmailVerified", markUserEmailVerified, userID, email)
+}
+
+// SetPendingEmail Set the new email of an email change
+func (r *authRepo) SetPendingEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	ctx, span := otel.Tracer.Start(ctx, "authRepo.SetPendingEmail")
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, setUserPendingEmail, email, userID); err != nil {
+		return errors.Wrap(err, "authRepo.SetPendingEmail.ExecContext")
+	}
+
+	return nil
+}
+
+// ConfirmPendingEmail Replace the email of a user with the verified pending one
+func (r *authRepo) ConfirmPendingEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	ctx, span := otel.Tracer.Start(ctx, "authRepo.ConfirmPendingEmail")
+	defer span.End()
+
+	return r.execAffectingOne(ctx, "authRepo.ConfirmPendingEmail", confirmUserPendingEmail, userID, email)
+}
+
+// UpdateAdminLevel Set the in-game admin level of a user
+func (r *authRepo) UpdateAdminLevel(ctx context.Context, userID uuid.UUID, adminLevel int) error {
+	ctx, span := otel.Tracer.Start(ctx, "authRepo.UpdateAdminLevel")
+	defer span.End()
+
+	return r.execAffectingOne(ctx, "authRepo.UpdateAdminLevel", updateUserAdminLevel, adminLevel, userID)
+}
+
+// execAffectingOne runs an update and turns "no row matched" into sql.ErrNoRows
+func (r *authRepo) execAffectingOne(ctx context.Context, op string, query string, args ...interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, op+".ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op+".RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, op+".rowsAffected")
+	}
+
+	return nil
+}
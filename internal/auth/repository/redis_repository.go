@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/auth"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Auth redis repository
+type authRedisRepo struct {
+	redisClient *redis.Client
+	cfg         *config.Config
+}
+
+// NewAuthRedisRepo Auth redis repository constructor
+func NewAuthRedisRepo(redisClient *redis.Client, cfg *config.Config) auth.RedisRepository {
+	return &authRedisRepo{redisClient: redisClient, cfg: cfg}
+}
+
+// SaveLoginChallenge Save the pending second login step of a user
+func (a *authRedisRepo) SaveLoginChallenge(ctx context.Context, challengeToken string, userID uuid.UUID, expire time.Duration) error {
+	ctx, span := otel.Tracer.Start(ctx, "authRedisRepo.SaveLoginChallenge")
+	defer span.End()
+
+	if err := a.redisClient.Set(ctx, a.challengeKey(challengeToken), userID.String(), expire).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.SaveLoginChallenge.Set")
+	}
+	return nil
+}
+
+// GetLoginChallenge Get the user of a pending second login step
+func (a *authRedisRepo) GetLoginChallenge(ctx context.Context, challengeToken string) (uuid.UUID, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authRedisRepo.GetLoginChallenge")
+	defer span.End()
+
+	value, err := a.redisClient.Get(ctx, a.challengeKey(challengeToken)).Result()
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.GetLoginChallenge.Get")
+	}
+
+	userID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.GetLoginChallenge.Parse")
+	}
+	return userID, nil
+}
+
+// IncrLoginChallengeAttempts Count a failed second login step attempt
+func (a *authRedisRepo) IncrLoginChallengeAttempts(ctx context.Context, challengeToken string, expire time.Duration) (int64, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authRedisRepo.IncrLoginChallengeAttempts")
+	defer span.End()
+
+	key := a.attemptsKey(challengeToken)
+	pipe := a.redisClient.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, expire)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, errors.Wrap(err, "authRedisRepo.IncrLoginChallengeAttempts.Exec")
+	}
+	return incr.Val(), nil
+}
+
+// DeleteLoginChallenge Delete a pending second login step
+func (a *authRedisRepo) DeleteLoginChallenge(ctx context.Context, challengeToken string) error {
+	ctx, span := otel.Tracer.Start(ctx, "authRedisRepo.DeleteLoginChallenge")
+	defer span.End()
+
+	if err := a.redisClient.Del(ctx, a.challengeKey(challengeToken), a.attemptsKey(challengeToken)).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.DeleteLoginChallenge.Del")
+	}
+	return nil
+}
+
+// SavePasswordResetToken Save a password reset token, replacing the previous one of the user
+func (a *authRedisRepo) SavePasswordResetToken(ctx context.Context, tokenHash string, userID uuid.UUID, expire time.Duration) error {
+	ctx, span := otel.Tracer.Start(ctx, "authRedisRepo.SavePasswordResetToken")
+	defer span.End()
+
+	previous, err := a.redisClient.Get(ctx, a.passwordResetUserKey(userID)).Result()
+	if err != nil && err != redis.Nil {
+		return errors.Wrap(err, "authRedisRepo.SavePasswordResetToken.Get")
+	}
+
+	pipe := a.redisClient.TxPipeline()
+	if previous != "" {
+		pipe.Del(ctx, a.passwordResetKey(previous))
+	}
+	pipe.Set(ctx, a.passwordResetKey(tokenHash), userID.String(), expire)
+	pipe.Set(ctx, a.passwordResetUserKey(userID), tokenHash, expire)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "authRedisRepo.SavePasswordResetToken.Exec")
+	}
+	return nil
+}
+
+// GetPasswordResetToken Get the user of a password reset token
+func (a *authRedisRepo) GetPasswordResetToken(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authRedisRepo.GetPasswordResetToken")
+	defer span.End()
+
+	value, err := a.redisClient.Get(ctx, a.passwordResetKey(tokenHash)).Result()
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.GetPasswordResetToken.Get")
+	}
+
+	userID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.GetPasswordResetToken.Parse")
+	}
+	return userID, nil
+}
+
+// ConsumePasswordResetToken Get and delete a password reset token atomically
+func (a *authRedisRepo) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authRedisRepo.ConsumePasswordResetToken")
+	defer span.End()
+
+	pipe := a.redisClient.TxPipeline()
+	get := pipe.Get(ctx, a.passwordResetKey(tokenHash))
+	pipe.Del(ctx, a.passwordResetKey(tokenHash))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.ConsumePasswordResetToken.Exec")
+	}
+
+	userID, err := uuid.Parse(get.Val())
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.ConsumePasswordResetToken.Parse")
+	}
+	if err = a.redisClient.Del(ctx, a.passwordResetUserKey(userID)).Err(); err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.ConsumePasswordResetToken.Del")
+	}
+	return userID, nil
+}
+
+// SaveEmailVerification Save an email verification token, replacing the previous one of the user
+func (a *authRedisRepo) SaveEmailVerification(ctx context.Context, tokenHash string, userID uuid.UUID, email string, expire time.Duration) error {
+	ctx, span := otel.Tracer.Start(ctx, "authRedisRepo.SaveEmailVerification")
+	defer span.End()
+
+	previous, err := a.redisClient.Get(ctx, a.emailVerificationUserKey(userID)).Result()
+	if err != nil && err != redis.Nil {
+		return errors.Wrap(err, "authRedisRepo.SaveEmailVerification.Get")
+	}
+
+	key := a.emailVerificationKey(tokenHash)
+	pipe := a.redisClient.TxPipeline()
+	if previous != "" {
+		pipe.Del(ctx, a.emailVerificationKey(previous))
+	}
+	pipe.HSet(ctx, key, "user_id", userID.String(), "email", email)
+	pipe.Expire(ctx, key, expire)
+	pipe.Set(ctx, a.emailVerificationUserKey(userID), tokenHash, expire)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "authRedisRepo.SaveEmailVerification.Exec")
+	}
+	return nil
+}
+
+// ConsumeEmailVerification Get and delete an email verification token atomically
+func (a *authRedisRepo) ConsumeEmailVerification(ctx context.Context, tokenHash string) (uuid.UUID, string, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authRedisRepo.ConsumeEmailVerification")
+	defer span.End()
+
+	key := a.emailVerificationKey(tokenHash)
+	pipe := a.redisClient.TxPipeline()
+	get := pipe.HGetAll(ctx, key)
+	pipe.Del(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return uuid.Nil, "", errors.Wrap(err, "authRedisRepo.ConsumeEmailVerification.Exec")
+	}
+
+	values := get.Val()
+	if len(values) == 0 {
+		return uuid.Nil, "", errors.Wrap(redis.Nil, "authRedisRepo.ConsumeEmailVerification.HGetAll")
+	}
+	userID, err := uuid.Parse(values["user_id"])
+	if err != nil {
+		return uuid.Nil, "", errors.Wrap(err, "authRedisRepo.ConsumeEmailVerification.Parse")
+	}
+	if err = a.redisClient.Del(ctx, a.emailVerificationUserKey(userID)).Err(); err != nil {
+		return uuid.Nil, "", errors.Wrap(err, "authRedisRepo.ConsumeEmailVerification.Del")
+	}
+	return userID, values["email"], nil
+}
+
+// AcquireEmailVerificationCooldown Start the resend cooldown of a user unless it is already running
+func (a *authRedisRepo) AcquireEmailVerificationCooldown(ctx context.Context, userID uuid.UUID, cooldown time.Duration) (bool, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authRedisRepo.AcquireEmailVerificationCooldown")
+	defer span.End()
+
+	acquired, err := a.redisClient.SetNX(ctx, a.emailVerificationCooldownKey(userID), 1, cooldown).Result()
+	if err != nil {
+		return false, errors.Wrap(err, "authRedisRepo.AcquireEmailVerificationCooldown.SetNX")
+	}
+	return acquired, nil
+}
+
+// EmailVerificationCooldownTTL Get the remaining resend cooldown of a user, 0 when it is over
+func (a *authRedisRepo) EmailVerificationCooldownTTL(ctx context.Context, userID uuid.UUID) (time.Duration, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authRedisRepo.EmailVerificationCooldownTTL")
+	defer span.End()
+
+	ttl, err := a.redisClient.PTTL(ctx, a.emailVerificationCooldownKey(userID)).Result()
+	if err != nil {
+		return 0, errors.Wrap(err, "authRedisRepo.EmailVerificationCooldownTTL.PTTL")
+	}
+	// PTTL answers -2 for a missing key and -1 for one without expiry
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (a *authRedisRepo) challengeKey(challengeToken string) string {
+	return a.cfg.TwoFactor.ChallengePrefix + ":" + challengeToken
+}
+
+func (a *authRedisRepo) attemptsKey(challengeToken string) string {
+	return a.cfg.TwoFactor.ChallengePrefix + ":" + challengeToken + ":attempts"
+}
+
+func (a *authRedisRepo) passwordResetKey(tokenHash string) string {
+	return a.cfg.PasswordReset.Prefix + ":" + tokenHash
+}
+
+func (a *authRedisRepo) passwordResetUserKey(userID uuid.UUID) string {
+	return a.cfg.PasswordReset.Prefix + ":user:" + userID.String()
+}
+
+func (a *authRedisRepo) emailVerificationKey(tokenHash string) string {
+	return a.cfg.EmailVerification.Prefix + ":" + tokenHash
+}
+
+func (a *authRedisRepo) emailVerificationUserKey(userID uuid.UUID) string {
+	return a.cfg.EmailVerification.Prefix + ":user:" + userID.String()
+}
+
+func (a *authRedisRepo) emailVerificationCooldownKey(userID uuid.UUID) string {
+	return a.cfg.EmailVerification.Prefix + ":cooldown:" + userID.String()
+}
@@ -0,0 +1,29 @@
+package repository
+
+const (
+	createUser = `INSERT INTO users (id, username, email, password) VALUES (?, ?, ?, ?)`
+
+	getUserByID = `SELECT id, username, email, email_verified_at, pending_email, password, password_algorithm, password_salt, admin_level, created_at, updated_at
+		FROM users WHERE id = ?`
+
+	getUserByLogin = `SELECT id, username, email, email_verified_at, pending_email, password, password_algorithm, password_salt, admin_level, created_at, updated_at
+		FROM users WHERE username = ? OR email = ? LIMIT 1`
+
+	getUserByUsername = `SELECT id, username, email, email_verified_at, pending_email, password, password_algorithm, password_salt, admin_level, created_at, updated_at
+		FROM users WHERE username = ?`
+
+	getUserByEmail = `SELECT id, username, email, email_verified_at, pending_email, password, password_algorithm, password_salt, admin_level, created_at, updated_at
+		FROM users WHERE email = ?`
+
+	updateUserPassword = `UPDATE users SET password = ?, password_algorithm = NULL, password_salt = NULL WHERE id = ?`
+
+	updateUserAdminLevel = `UPDATE users SET admin_level = ? WHERE id = ?`
+
+	markUserEmailVerified = `UPDATE users SET email_verified_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND email = ? AND email_verified_at IS NULL`
+
+	setUserPendingEmail = `UPDATE users SET pending_email = ? WHERE id = ?`
+
+	confirmUserPendingEmail = `UPDATE users SET email = pending_email, pending_email = NULL, email_verified_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND pending_email = ?`
+)
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/caller"
+
+	"github.com/google/uuid"
+	"github.com/iamaul/go-evonix-backend-api/pkg/jwt"
+)
+
+// UseCase Auth UseCase interface
+type UseCase interface {
+	// Register creates an account and mails the verification link of its email
+	Register(ctx context.Context, input *models.RegisterRequest) (*models.User, error)
+	Login(ctx context.Context, input *models.LoginRequest) (*models.LoginResult, error)
+	LoginTwoFactor(ctx context.Context, input *models.LoginTwoFactorRequest) (*models.LoginResult, error)
+	Refresh(ctx context.Context, refreshToken string) (*jwt.Tokens, error)
+	Logout(ctx context.Context, principal *caller.Principal) error
+	// ForgotPassword mails a reset token, it behaves the same whether or not the email exists
+	ForgotPassword(ctx context.Context, input *models.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, input *models.ResetPasswordRequest) error
+	// SendEmailVerification mails a verification token for the current email of a new account
+	SendEmailVerification(ctx context.Context, user *models.User) error
+	// VerifyEmail verifies the current email or completes a pending email change
+	VerifyEmail(ctx context.Context, input *models.VerifyEmailRequest) error
+	ResendEmailVerification(ctx context.Context, userID uuid.UUID) error
+	// ChangeEmail keeps the current email active until the new one is verified
+	ChangeEmail(ctx context.Context, userID uuid.UUID, input *models.ChangeEmailRequest) error
+}
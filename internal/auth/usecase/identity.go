@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/auth"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/rbac"
+	"github.com/iamaul/go-evonix-backend-api/pkg/jwt"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/google/uuid"
+)
+
+// Identity loader of the token manager
+type identityLoader struct {
+	authRepo auth.Repository
+	rbacRepo rbac.Repository
+}
+
+// NewIdentityLoader Identity loader constructor, refreshed tokens get the current state of the user
+func NewIdentityLoader(authRepo auth.Repository, rbacRepo rbac.Repository) jwt.IdentityLoader {
+	return &identityLoader{authRepo: authRepo, rbacRepo: rbacRepo}
+}
+
+// LoadIdentity Load the identity of a user from the database
+func (l *identityLoader) LoadIdentity(ctx context.Context, userID string) (jwt.Identity, error) {
+	ctx, span := otel.Tracer.Start(ctx, "identityLoader.LoadIdentity")
+	defer span.End()
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return jwt.Identity{}, err
+	}
+
+	user, err := l.authRepo.GetByID(ctx, id)
+	if err != nil {
+		return jwt.Identity{}, err
+	}
+
+	userRoles, err := l.rbacRepo.ListUserRoles(ctx, id)
+	if err != nil {
+		return jwt.Identity{}, err
+	}
+	assigned := make([]string, 0, len(userRoles))
+	for _, userRole := range userRoles {
+		assigned = append(assigned, userRole.RoleID)
+	}
+
+	return jwt.Identity{
+		UserID:        user.ID.String(),
+		AdminLevel:    user.AdminLevel,
+		Roles:         models.EffectiveRoles(user.AdminLevel, assigned),
+		EmailVerified: user.IsEmailVerified(),
+	}, nil
+}
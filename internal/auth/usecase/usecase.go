@@ -0,0 +1,720 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/alts"
+	"github.com/iamaul/go-evonix-backend-api/internal/audit"
+	"github.com/iamaul/go-evonix-backend-api/internal/auth"
+	"github.com/iamaul/go-evonix-backend-api/internal/loginguard"
+	"github.com/iamaul/go-evonix-backend-api/internal/loginhistory"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/session"
+	"github.com/iamaul/go-evonix-backend-api/internal/twofactor"
+	"github.com/iamaul/go-evonix-backend-api/pkg/caller"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/hash"
+	"github.com/iamaul/go-evonix-backend-api/pkg/jwt"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/mailer"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/password"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	challengeTokenBytes     = 32
+	passwordResetTokenBytes = 32
+	verificationTokenBytes  = 32
+	dummyPasswordBytes      = 32
+)
+
+// Auth UseCase
+type authUC struct {
+	cfg            *config.Config
+	authRepo       auth.Repository
+	redisRepo      auth.RedisRepository
+	twoFactorUC    twofactor.UseCase
+	sessionUC      session.UseCase
+	loginGuardUC   loginguard.UseCase
+	auditUC        audit.UseCase
+	loginHistoryUC loginhistory.UseCase
+	altsUC         alts.UseCase
+	tokenManager   jwt.TokenManager
+	identities     jwt.IdentityLoader
+	hasher         hash.PasswordHasher
+	policy         *password.Policy
+	mailer         mailer.Mailer
+	templates      *mailer.Templates
+	logger         logger.Logger
+	// dummyHash is verified for unknown logins so they take as long as known ones
+	dummyHash string
+}
+
+// NewAuthUseCase Auth UseCase constructor
+func NewAuthUseCase(
+	cfg *config.Config,
+	authRepo auth.Repository,
+	redisRepo auth.RedisRepository,
+	twoFactorUC twofactor.UseCase,
+	sessionUC session.UseCase,
+	loginGuardUC loginguard.UseCase,
+	auditUC audit.UseCase,
+	loginHistoryUC loginhistory.UseCase,
+	altsUC alts.UseCase,
+	tokenManager jwt.TokenManager,
+	identities jwt.IdentityLoader,
+	hasher hash.PasswordHasher,
+	policy *password.Policy,
+	mailer mailer.Mailer,
+	templates *mailer.Templates,
+	log logger.Logger,
+) auth.UseCase {
+	dummyHash, err := newDummyHash(hasher)
+	if err != nil {
+		log.Errorf("authUC.NewAuthUseCase.newDummyHash, Error: %s", err)
+	}
+
+	return &authUC{
+		cfg:            cfg,
+		authRepo:       authRepo,
+		redisRepo:      redisRepo,
+		twoFactorUC:    twoFactorUC,
+		sessionUC:      sessionUC,
+		loginGuardUC:   loginGuardUC,
+		auditUC:        auditUC,
+		loginHistoryUC: loginHistoryUC,
+		altsUC:         altsUC,
+		tokenManager:   tokenManager,
+		identities:     identities,
+		hasher:         hasher,
+		policy:         policy,
+		mailer:         mailer,
+		templates:      templates,
+		logger:         log,
+		dummyHash:      dummyHash,
+	}
+}
+
+// Register Create an account with a unique username and email, the email still has to be verified
+func (u *authUC) Register(ctx context.Context, input *models.RegisterRequest) (*models.User, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authUC.Register")
+	defer span.End()
+
+	user := &models.User{
+		ID:       uuid.New(),
+		Username: strings.TrimSpace(input.Username),
+		Email:    strings.TrimSpace(input.Email),
+	}
+	if err := u.validateUsername(user.Username); err != nil {
+		return nil, err
+	}
+	if err := u.validatePassword(input.Password, user); err != nil {
+		return nil, err
+	}
+
+	// Checked up front for a friendly answer, the unique keys still catch concurrent registrations
+	if _, err := u.authRepo.GetByUsername(ctx, user.Username); err == nil {
+		return nil, httpErr.NewRestError(http.StatusConflict, httpErr.ErrExistsUsernameError.Error(), map[string]string{"username": "is already taken"})
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if _, err := u.authRepo.GetByEmail(ctx, user.Email); err == nil {
+		return nil, httpErr.NewRestError(http.StatusConflict, httpErr.ErrExistsEmailError.Error(), map[string]string{"email": "is already taken"})
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	passwordHash, err := u.hasher.Hash(input.Password)
+	if err != nil {
+		return nil, err
+	}
+	user.Password = passwordHash
+
+	created, err := u.authRepo.Create(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	u.auditUC.Record(ctx, &models.AuditLog{
+		Action:   models.AuditAccountRegistered,
+		ActorID:  &created.ID,
+		TargetID: &created.ID,
+		Changes:  models.AuditChanges{"username": {New: created.Username}, "email": {New: created.Email}},
+	})
+
+	// The account exists either way, a lost mail is fixed with a resend
+	if err = u.SendEmailVerification(ctx, created); err != nil {
+		u.logger.Errorf("authUC.Register.SendEmailVerification, UserID: %s, Error: %s", created.ID, err)
+	}
+
+	return created, nil
+}
+
+// Login Check the password, then either issue tokens or start the second step
+func (u *authUC) Login(ctx context.Context, input *models.LoginRequest) (*models.LoginResult, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authUC.Login")
+	defer span.End()
+
+	user, err := u.authRepo.GetByLogin(ctx, input.Login)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// Unknown logins are throttled too, otherwise lockouts would tell which accounts exist
+	account := strings.ToLower(strings.TrimSpace(input.Login))
+	if user != nil {
+		account = user.ID.String()
+	}
+	if err = u.loginGuardUC.Check(ctx, account, input.IP); err != nil {
+		if user != nil {
+			u.loginHistoryUC.Record(ctx, user.ID, models.LoginRecordLockedOut)
+		}
+		return nil, err
+	}
+
+	// Unknown logins spend a hash too, otherwise the response time tells which accounts exist
+	passwordHash := u.dummyHash
+	if user != nil {
+		passwordHash = storedPassword(user)
+	}
+	if !u.hasher.IsEqual(passwordHash, input.Password) || user == nil {
+		if user != nil {
+			u.auditUC.Record(ctx, &models.AuditLog{Action: models.AuditLoginFailed, TargetID: &user.ID})
+			u.loginHistoryUC.Record(ctx, user.ID, models.LoginRecordFailed)
+		}
+		if err = u.loginGuardUC.RecordFailure(ctx, account, input.IP); err != nil {
+			return nil, err
+		}
+		return nil, wrongCredentialsError()
+	}
+	u.rehashPassword(ctx, user, input.Password)
+
+	twoFactorEnabled, err := u.twoFactorUC.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactorEnabled {
+		challengeToken, err := newRandomToken(challengeTokenBytes)
+		if err != nil {
+			return nil, err
+		}
+		if err = u.redisRepo.SaveLoginChallenge(ctx, challengeToken, user.ID, u.cfg.TwoFactor.ChallengeExpire); err != nil {
+			return nil, err
+		}
+		return &models.LoginResult{TwoFactorRequired: true, ChallengeToken: challengeToken}, nil
+	}
+
+	// The failures are only cleared once the whole login succeeded, not after the password alone
+	if err = u.loginGuardUC.RecordSuccess(ctx, account); err != nil {
+		return nil, err
+	}
+	return u.issueTokens(ctx, user, input.IP, input.UserAgent)
+}
+
+// LoginTwoFactor Complete a login with a TOTP or recovery code
+func (u *authUC) LoginTwoFactor(ctx context.Context, input *models.LoginTwoFactorRequest) (*models.LoginResult, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authUC.LoginTwoFactor")
+	defer span.End()
+
+	userID, err := u.redisRepo.GetLoginChallenge(ctx, input.ChallengeToken)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, invalidChallengeError()
+		}
+		return nil, err
+	}
+
+	// Wrong codes count against the account like wrong passwords, new challenges don't reset them
+	account := userID.String()
+	if err = u.loginGuardUC.Check(ctx, account, input.IP); err != nil {
+		u.loginHistoryUC.Record(ctx, userID, models.LoginRecordLockedOut)
+		return nil, err
+	}
+
+	if err = u.twoFactorUC.Verify(ctx, userID, &models.TwoFactorCodeRequest{
+		Code:         input.Code,
+		RecoveryCode: input.RecoveryCode,
+	}); err != nil {
+		u.auditUC.Record(ctx, &models.AuditLog{Action: models.AuditLoginTwoFactorFailed, TargetID: &userID})
+		u.loginHistoryUC.Record(ctx, userID, models.LoginRecordTwoFactorFailed)
+		if guardErr := u.loginGuardUC.RecordFailure(ctx, account, input.IP); guardErr != nil {
+			return nil, guardErr
+		}
+		attempts, incrErr := u.redisRepo.IncrLoginChallengeAttempts(ctx, input.ChallengeToken, u.cfg.TwoFactor.ChallengeExpire)
+		if incrErr != nil {
+			return nil, incrErr
+		}
+		if attempts >= int64(u.cfg.TwoFactor.MaxAttempts) {
+			// Too many guesses, the player has to enter the password again
+			if delErr := u.redisRepo.DeleteLoginChallenge(ctx, input.ChallengeToken); delErr != nil {
+				return nil, delErr
+			}
+		}
+		return nil, err
+	}
+
+	if err = u.redisRepo.DeleteLoginChallenge(ctx, input.ChallengeToken); err != nil {
+		return nil, err
+	}
+	if err = u.loginGuardUC.RecordSuccess(ctx, account); err != nil {
+		return nil, err
+	}
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.issueTokens(ctx, user, input.IP, input.UserAgent)
+}
+
+// Refresh Rotate a refresh token of a session that is still active
+func (u *authUC) Refresh(ctx context.Context, refreshToken string) (*jwt.Tokens, error) {
+	ctx, span := otel.Tracer.Start(ctx, "authUC.Refresh")
+	defer span.End()
+
+	tokens, err := u.tokenManager.Refresh(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := u.tokenManager.Parse(tokens.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := claims.UserUUID()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = u.sessionUC.Touch(ctx, userID, claims.SessionID); err != nil {
+		// The session expired or was killed, its refresh tokens must not outlive it
+		if revokeErr := u.tokenManager.RevokeFamily(ctx, claims.SessionID); revokeErr != nil {
+			return nil, revokeErr
+		}
+		return nil, err
+	}
+
+	return &tokens, nil
+}
+
+// Logout Revoke the session of the principal
+func (u *authUC) Logout(ctx context.Context, principal *caller.Principal) error {
+	ctx, span := otel.Tracer.Start(ctx, "authUC.Logout")
+	defer span.End()
+
+	if err := u.tokenManager.RevokeSession(ctx, principal.SessionID, principal.TokenID, principal.ExpiresAt); err != nil {
+		return err
+	}
+	return u.sessionUC.Revoke(ctx, principal.UserID, principal.SessionID)
+}
+
+// ForgotPassword Mail a single-use password reset token
+func (u *authUC) ForgotPassword(ctx context.Context, input *models.ForgotPasswordRequest) error {
+	ctx, span := otel.Tracer.Start(ctx, "authUC.ForgotPassword")
+	defer span.End()
+
+	user, err := u.authRepo.GetByEmail(ctx, input.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Answer exactly as if the email existed so accounts can't be enumerated
+			return nil
+		}
+		return err
+	}
+
+	token, err := newRandomToken(passwordResetTokenBytes)
+	if err != nil {
+		return err
+	}
+	if err = u.redisRepo.SavePasswordResetToken(ctx, hashToken(token), user.ID, u.cfg.PasswordReset.Expire); err != nil {
+		return err
+	}
+
+	// Mails are only queued here so the response time doesn't tell whether the email exists
+	u.sendMail(ctx, user, user.Email, mailer.TemplatePasswordReset, mailer.PasswordResetData{
+		Username: user.Username,
+		Link:     u.cfg.PasswordReset.URL + "?token=" + url.QueryEscape(token),
+		Expire:   u.cfg.PasswordReset.Expire,
+	})
+
+	return nil
+}
+
+// ResetPassword Set a new password with a reset token and revoke every session and personal access token of the user
+func (u *authUC) ResetPassword(ctx context.Context, input *models.ResetPasswordRequest) error {
+	ctx, span := otel.Tracer.Start(ctx, "authUC.ResetPassword")
+	defer span.End()
+
+	// The token is only used up once the new password passes the policy, so the player can retry
+	userID, err := u.redisRepo.GetPasswordResetToken(ctx, hashToken(input.Token))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return httpErr.NewBadRequestError(httpErr.ErrInvalidPasswordResetToken.Error())
+		}
+		return err
+	}
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err = u.validatePassword(input.Password, user); err != nil {
+		return err
+	}
+
+	if _, err = u.redisRepo.ConsumePasswordResetToken(ctx, hashToken(input.Token)); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return httpErr.NewBadRequestError(httpErr.ErrInvalidPasswordResetToken.Error())
+		}
+		return err
+	}
+
+	passwordHash, err := u.hasher.Hash(input.Password)
+	if err != nil {
+		return err
+	}
+	if err = u.authRepo.UpdatePassword(ctx, userID, passwordHash); err != nil {
+		return err
+	}
+
+	u.auditUC.Record(ctx, &models.AuditLog{Action: models.AuditPasswordReset, ActorID: &userID, TargetID: &userID})
+
+	if err = u.tokenManager.RevokeUser(ctx, userID.String()); err != nil {
+		return err
+	}
+	return u.sessionUC.RevokeAll(ctx, userID, "")
+}
+
+// SendEmailVerification Mail a verification token for the current email of a user
+func (u *authUC) SendEmailVerification(ctx context.Context, user *models.User) error {
+	ctx, span := otel.Tracer.Start(ctx, "authUC.SendEmailVerification")
+	defer span.End()
+
+	if user.IsEmailVerified() {
+		return httpErr.NewBadRequestError(httpErr.ErrEmailAlreadyVerified.Error())
+	}
+	if _, err := u.redisRepo.AcquireEmailVerificationCooldown(ctx, user.ID, u.cfg.EmailVerification.ResendCooldown); err != nil {
+		return err
+	}
+
+	return u.sendEmailVerification(ctx, user, user.Email)
+}
+
+// VerifyEmail Verify the email a token was sent to
+func (u *authUC) VerifyEmail(ctx context.Context, input *models.VerifyEmailRequest) error {
+	ctx, span := otel.Tracer.Start(ctx, "authUC.VerifyEmail")
+	defer span.End()
+
+	userID, email, err := u.redisRepo.ConsumeEmailVerification(ctx, hashToken(input.Token))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return invalidEmailVerificationError()
+		}
+		return err
+	}
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// The address may have changed since the token was sent, only the one it was sent to can be verified
+	auditLog := &models.AuditLog{ActorID: &user.ID, TargetID: &user.ID}
+	switch {
+	case user.PendingEmail != nil && strings.EqualFold(*user.PendingEmail, email):
+		err = u.authRepo.ConfirmPendingEmail(ctx, user.ID, *user.PendingEmail)
+		auditLog.Action = models.AuditEmailChanged
+		auditLog.Changes = models.AuditChanges{"email": {Old: user.Email, New: *user.PendingEmail}}
+	case strings.EqualFold(user.Email, email):
+		err = u.authRepo.MarkEmailVerified(ctx, user.ID, user.Email)
+		auditLog.Action = models.AuditEmailVerified
+		auditLog.Changes = models.AuditChanges{"email_verified": {Old: false, New: true}}
+	default:
+		return invalidEmailVerificationError()
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return invalidEmailVerificationError()
+		}
+		return err
+	}
+
+	u.auditUC.Record(ctx, auditLog)
+	return nil
+}
+
+// ResendEmailVerification Mail a new verification token for the pending or unverified email of a user
+func (u *authUC) ResendEmailVerification(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := otel.Tracer.Start(ctx, "authUC.ResendEmailVerification")
+	defer span.End()
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	email := user.Email
+	if user.PendingEmail != nil {
+		email = *user.PendingEmail
+	} else if user.IsEmailVerified() {
+		return httpErr.NewBadRequestError(httpErr.ErrEmailAlreadyVerified.Error())
+	}
+
+	if err = u.acquireEmailVerificationCooldown(ctx, user.ID); err != nil {
+		return err
+	}
+
+	return u.sendEmailVerification(ctx, user, email)
+}
+
+// ChangeEmail Start an email change, the new address only replaces the current one once it is verified
+func (u *authUC) ChangeEmail(ctx context.Context, userID uuid.UUID, input *models.ChangeEmailRequest) error {
+	ctx, span := otel.Tracer.Start(ctx, "authUC.ChangeEmail")
+	defer span.End()
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !u.hasher.IsEqual(storedPassword(user), input.Password) {
+		return wrongCredentialsError()
+	}
+	if strings.EqualFold(user.Email, input.Email) {
+		return httpErr.NewBadRequestError("new email is the current email")
+	}
+
+	if _, err = u.authRepo.GetByEmail(ctx, input.Email); err == nil {
+		return httpErr.NewRestError(http.StatusConflict, httpErr.ErrExistsEmailError.Error(), map[string]string{"email": "is already taken"})
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	// Every change mails the new and the old address, so it shares the resend cooldown
+	if err = u.acquireEmailVerificationCooldown(ctx, user.ID); err != nil {
+		return err
+	}
+
+	if err = u.authRepo.SetPendingEmail(ctx, user.ID, input.Email); err != nil {
+		return err
+	}
+	u.auditUC.Record(ctx, &models.AuditLog{
+		Action:   models.AuditEmailChangeRequested,
+		TargetID: &user.ID,
+		Changes:  models.AuditChanges{"pending_email": {Old: user.PendingEmail, New: input.Email}},
+	})
+	if err = u.sendEmailVerification(ctx, user, input.Email); err != nil {
+		return err
+	}
+
+	u.sendMail(ctx, user, user.Email, mailer.TemplateEmailChangeNotice, mailer.EmailChangeNoticeData{
+		Username: user.Username,
+		NewEmail: input.Email,
+	})
+
+	return nil
+}
+
+// acquireEmailVerificationCooldown returns a 429 with the remaining time while the user is in the resend cooldown
+func (u *authUC) acquireEmailVerificationCooldown(ctx context.Context, userID uuid.UUID) error {
+	acquired, err := u.redisRepo.AcquireEmailVerificationCooldown(ctx, userID, u.cfg.EmailVerification.ResendCooldown)
+	if err != nil {
+		return err
+	}
+	if acquired {
+		return nil
+	}
+
+	ttl, err := u.redisRepo.EmailVerificationCooldownTTL(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return httpErr.NewTooManyRequestsError(httpErr.ErrEmailVerificationCooldown.Error(), ttl, map[string]int{
+		"retry_after": int((ttl + time.Second - 1) / time.Second),
+	})
+}
+
+func (u *authUC) sendEmailVerification(ctx context.Context, user *models.User, email string) error {
+	token, err := newRandomToken(verificationTokenBytes)
+	if err != nil {
+		return err
+	}
+	if err = u.redisRepo.SaveEmailVerification(ctx, hashToken(token), user.ID, email, u.cfg.EmailVerification.Expire); err != nil {
+		return err
+	}
+
+	u.sendMail(ctx, user, email, mailer.TemplateEmailVerification, mailer.EmailVerificationData{
+		Username: user.Username,
+		Link:     u.cfg.EmailVerification.URL + "?token=" + url.QueryEscape(token),
+		Expire:   u.cfg.EmailVerification.Expire,
+	})
+
+	return nil
+}
+
+// sendMail queues a templated mail in the language of the request, a failure is only logged
+// so callers answer the same whether or not a mail went out
+func (u *authUC) sendMail(ctx context.Context, user *models.User, to, template string, data interface{}) {
+	msg, err := u.templates.Render(template, mailer.LanguageFromContext(ctx), data)
+	if err != nil {
+		u.logger.Errorf("authUC.sendMail.Render, UserID: %s, Template: %s, Error: %s", user.ID, template, err)
+		return
+	}
+	msg.To = []string{to}
+
+	if err = u.mailer.Send(ctx, msg); err != nil {
+		u.logger.Errorf("authUC.sendMail.Send, UserID: %s, Template: %s, Error: %s", user.ID, template, err)
+	}
+}
+
+func (u *authUC) issueTokens(ctx context.Context, user *models.User, ip, userAgent string) (*models.LoginResult, error) {
+	identity, err := u.identities.LoadIdentity(ctx, user.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := u.tokenManager.NewTokens(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := u.tokenManager.Parse(tokens.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = u.sessionUC.Create(ctx, user.ID, claims.SessionID, ip, userAgent); err != nil {
+		return nil, err
+	}
+	u.auditUC.Record(ctx, &models.AuditLog{
+		Action:   models.AuditLoginSucceeded,
+		ActorID:  &user.ID,
+		TargetID: &user.ID,
+		Changes:  models.AuditChanges{"session_id": {New: claims.SessionID}},
+	})
+	u.altsUC.RecordLogin(ctx, user.ID)
+	if record := u.loginHistoryUC.Record(ctx, user.ID, models.LoginRecordSucceeded); record.NewCountry {
+		u.sendMail(ctx, user, user.Email, mailer.TemplateNewCountryLogin, mailer.NewCountryLoginData{
+			Username: user.Username,
+			Country:  record.Country,
+			City:     record.City,
+			IP:       record.IP,
+			Time:     time.Now(),
+		})
+	}
+
+	return &models.LoginResult{User: user, Tokens: &tokens, SessionID: claims.SessionID}, nil
+}
+
+// newDummyHash hashes a random password with the configured algorithm, nothing ever matches it
+func newDummyHash(hasher hash.PasswordHasher) (string, error) {
+	password, err := newRandomToken(dummyPasswordBytes)
+	if err != nil {
+		return "", err
+	}
+	return hasher.Hash(password)
+}
+
+func newRandomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken keeps raw single-use tokens out of redis
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func wrongCredentialsError() error {
+	return httpErr.NewRestError(http.StatusUnauthorized, httpErr.WrongCredentials, nil)
+}
+
+func invalidChallengeError() error {
+	return httpErr.NewRestError(http.StatusUnauthorized, httpErr.ErrInvalidLoginChallenge.Error(), nil)
+}
+
+func invalidEmailVerificationError() error {
+	return httpErr.NewBadRequestError(httpErr.ErrInvalidEmailVerificationToken.Error())
+}
+
+// rehashPassword upgrades a hash made with an old algorithm or weaker parameters while the
+// plain password is known, a failure only costs another try on the next login
+func (u *authUC) rehashPassword(ctx context.Context, user *models.User, password string) {
+	if !u.hasher.NeedsRehash(storedPassword(user)) {
+		return
+	}
+
+	passwordHash, err := u.hasher.Hash(password)
+	if err != nil {
+		u.logger.Errorf("authUC.rehashPassword.Hash, UserID: %s, Error: %s", user.ID, err)
+		return
+	}
+	if err = u.authRepo.UpdatePassword(ctx, user.ID, passwordHash); err != nil {
+		u.logger.Errorf("authUC.rehashPassword.UpdatePassword, UserID: %s, Error: %s", user.ID, err)
+		return
+	}
+	user.Password, user.PasswordAlgorithm, user.PasswordSalt = passwordHash, nil, nil
+}
+
+// validateUsername refuses reserved usernames and usernames that pass as staff, the characters
+// and length are already checked by the samp_username validator tag
+func (u *authUC) validateUsername(username string) error {
+	for _, reserved := range u.cfg.Registration.ReservedUsernames {
+		if strings.EqualFold(username, reserved) {
+			return httpErr.NewBadRequestError(map[string]string{"username": "is reserved"})
+		}
+	}
+
+	normalized := staffLookalikes.Replace(strings.ToLower(username))
+	for _, word := range u.cfg.Registration.StaffNameWords {
+		if word != "" && strings.Contains(normalized, strings.ToLower(word)) {
+			return httpErr.NewBadRequestError(map[string]string{"username": "must not pass as staff"})
+		}
+	}
+	return nil
+}
+
+// staffLookalikes spells digits and symbols as the letters they imitate and drops separators,
+// so A_d_m_1_n and M0d3rator still read as staff
+var staffLookalikes = strings.NewReplacer(
+	"0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "$", "s", "@", "a",
+	"_", "", ".", "", "=", "", "[", "", "]", "", "(", "", ")", "",
+)
+
+// validatePassword checks a new password against the password policy, every broken rule is returned
+func (u *authUC) validatePassword(pwd string, user *models.User) error {
+	if violations := u.policy.Validate(pwd, user.Username); len(violations) > 0 {
+		return httpErr.NewRestError(http.StatusBadRequest, httpErr.ErrWeakPassword.Error(), violations)
+	}
+	return nil
+}
+
+// storedPassword returns the password hash of the user in a format the hasher verifies, legacy
+// digests of the gamemode keep their algorithm and salt in separate columns
+func storedPassword(user *models.User) string {
+	if user.PasswordAlgorithm == nil && user.PasswordSalt == nil {
+		return user.Password
+	}
+
+	var algorithm, salt string
+	if user.PasswordAlgorithm != nil {
+		algorithm = *user.PasswordAlgorithm
+	}
+	if user.PasswordSalt != nil {
+		salt = *user.PasswordSalt
+	}
+	return hash.EncodeLegacy(algorithm, salt, user.Password)
+}
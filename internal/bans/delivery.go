@@ -0,0 +1,12 @@
+package bans
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Bans HTTP Handlers interface
+type Handlers interface {
+	List() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+	Create() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+}
@@ -0,0 +1,151 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/bans"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Bans handlers
+type bansHandlers struct {
+	cfg    *config.Config
+	bansUC bans.UseCase
+	logger logger.Logger
+}
+
+// NewBansHandlers Bans handlers constructor
+func NewBansHandlers(cfg *config.Config, bansUC bans.UseCase, log logger.Logger) bans.Handlers {
+	return &bansHandlers{cfg: cfg, bansUC: bansUC, logger: log}
+}
+
+// List Get a page of bans, expired ones included
+func (h *bansHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "bansHandlers.List")
+		defer span.End()
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErr.NewBadRequestError(httpErr.ErrBadQueryParams.Error()))
+		}
+
+		list, err := h.bansUC.List(ctx, pq)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: list, Success: true})
+	}
+}
+
+// GetByID Get a ban
+func (h *bansHandlers) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "bansHandlers.GetByID")
+		defer span.End()
+
+		banID, err := banIDParam(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		ban, err := h.bansUC.GetByID(ctx, banID)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: ban, Success: true})
+	}
+}
+
+// Create Ban an address, a network or an ASN
+func (h *bansHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "bansHandlers.Create")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		input := &models.BanRequest{}
+		if err = utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		ban, err := h.bansUC.Create(ctx, principal.UserID, input)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusCreated, utils.ResponseJSON{Code: http.StatusCreated, Result: ban, Success: true})
+	}
+}
+
+// Update Replace a ban
+func (h *bansHandlers) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "bansHandlers.Update")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		banID, err := banIDParam(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		input := &models.BanRequest{}
+		if err = utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		ban, err := h.bansUC.Update(ctx, principal.UserID, banID, input)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: ban, Success: true})
+	}
+}
+
+// Delete Lift a ban
+func (h *bansHandlers) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "bansHandlers.Delete")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		banID, err := banIDParam(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.bansUC.Delete(ctx, principal.UserID, banID); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func banIDParam(c echo.Context) (int64, error) {
+	banID, err := strconv.ParseInt(c.Param("ban_id"), 10, 64)
+	if err != nil || banID <= 0 {
+		return 0, httpErr.NewBadRequestError(map[string]string{"ban_id": "must be a positive integer"})
+	}
+	return banID, nil
+}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/internal/bans"
+	"github.com/iamaul/go-evonix-backend-api/internal/middleware"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MapBanAdminRoutes Map ban list routes of the staff tools
+func MapBanAdminRoutes(adminGroup *echo.Group, h bans.Handlers, mw *middleware.MiddlewareManager) {
+	adminGroup.GET("/bans", h.List(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionBansRead))
+	adminGroup.GET("/bans/:ban_id", h.GetByID(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionBansRead))
+	adminGroup.POST("/bans", h.Create(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionBansWrite))
+	adminGroup.PUT("/bans/:ban_id", h.Update(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionBansWrite))
+	adminGroup.DELETE("/bans/:ban_id", h.Delete(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionBansWrite))
+}
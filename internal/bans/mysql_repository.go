@@ -0,0 +1,22 @@
+package bans
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+)
+
+// Repository Bans MySQL repository interface
+type Repository interface {
+	Create(ctx context.Context, ban *models.Ban) (*models.Ban, error)
+	Update(ctx context.Context, ban *models.Ban) (*models.Ban, error)
+	Delete(ctx context.Context, banID int64) error
+	GetByID(ctx context.Context, banID int64) (*models.Ban, error)
+	// GetByValue returns the ban of a type and value, bans stay in the table after they expire
+	GetByValue(ctx context.Context, banType, value string) (*models.Ban, error)
+	// List returns a page of bans, newest first, and the total count
+	List(ctx context.Context, pq *utils.PaginationQuery) ([]*models.Ban, int, error)
+	// ListActive returns every ban that hasn't expired
+	ListActive(ctx context.Context) ([]*models.Ban, error)
+}
@@ -0,0 +1,10 @@
+package bans
+
+import "context"
+
+// RedisRepository Bans Redis repository interface, it tells every instance that the ban list changed
+type RedisRepository interface {
+	PublishChange(ctx context.Context) error
+	// SubscribeChanges receives a value for every published change until ctx is done
+	SubscribeChanges(ctx context.Context) (<-chan struct{}, error)
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/bans"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Bans Repository
+type bansRepo struct {
+	db *sqlx.DB
+}
+
+// NewBansRepository Bans Repository constructor
+func NewBansRepository(db *sqlx.DB) bans.Repository {
+	return &bansRepo{db: db}
+}
+
+// Create Add a ban
+func (r *bansRepo) Create(ctx context.Context, ban *models.Ban) (*models.Ban, error) {
+	ctx, span := otel.Tracer.Start(ctx, "bansRepo.Create")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, createBan, ban.Type, ban.Value, ban.Reason, ban.BannedBy, ban.ExpiresAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "bansRepo.Create.ExecContext")
+	}
+	banID, err := result.LastInsertId()
+	if err != nil {
+		return nil, errors.Wrap(err, "bansRepo.Create.LastInsertId")
+	}
+
+	return r.GetByID(ctx, banID)
+}
+
+// Update Replace a ban
+func (r *bansRepo) Update(ctx context.Context, ban *models.Ban) (*models.Ban, error) {
+	ctx, span := otel.Tracer.Start(ctx, "bansRepo.Update")
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, updateBan, ban.Type, ban.Value, ban.Reason, ban.BannedBy, ban.ExpiresAt, ban.ID); err != nil {
+		return nil, errors.Wrap(err, "bansRepo.Update.ExecContext")
+	}
+
+	return r.GetByID(ctx, ban.ID)
+}
+
+// Delete Lift a ban
+func (r *bansRepo) Delete(ctx context.Context, banID int64) error {
+	ctx, span := otel.Tracer.Start(ctx, "bansRepo.Delete")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteBan, banID)
+	if err != nil {
+		return errors.Wrap(err, "bansRepo.Delete.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "bansRepo.Delete.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "bansRepo.Delete.rowsAffected")
+	}
+
+	return nil
+}
+
+// GetByID Get a ban by id
+func (r *bansRepo) GetByID(ctx context.Context, banID int64) (*models.Ban, error) {
+	ctx, span := otel.Tracer.Start(ctx, "bansRepo.GetByID")
+	defer span.End()
+
+	ban := &models.Ban{}
+	if err := r.db.GetContext(ctx, ban, getBanByID, banID); err != nil {
+		return nil, errors.Wrap(err, "bansRepo.GetByID.GetContext")
+	}
+
+	return ban, nil
+}
+
+// GetByValue Get the ban of an address, a network or an ASN, expired or not
+func (r *bansRepo) GetByValue(ctx context.Context, banType, value string) (*models.Ban, error) {
+	ctx, span := otel.Tracer.Start(ctx, "bansRepo.GetByValue")
+	defer span.End()
+
+	ban := &models.Ban{}
+	if err := r.db.GetContext(ctx, ban, getBanByValue, banType, value); err != nil {
+		return nil, errors.Wrap(err, "bansRepo.GetByValue.GetContext")
+	}
+
+	return ban, nil
+}
+
+// List Get a page of bans
+func (r *bansRepo) List(ctx context.Context, pq *utils.PaginationQuery) ([]*models.Ban, int, error) {
+	ctx, span := otel.Tracer.Start(ctx, "bansRepo.List")
+	defer span.End()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, countBans); err != nil {
+		return nil, 0, errors.Wrap(err, "bansRepo.List.GetContext")
+	}
+
+	list := make([]*models.Ban, 0, pq.GetLimit())
+	if totalCount == 0 {
+		return list, 0, nil
+	}
+
+	if err := r.db.SelectContext(ctx, &list, listBans, pq.GetLimit(), pq.GetOffset()); err != nil {
+		return nil, 0, errors.Wrap(err, "bansRepo.List.SelectContext")
+	}
+
+	return list, totalCount, nil
+}
+
+// ListActive Get every ban that hasn't expired
+func (r *bansRepo) ListActive(ctx context.Context) ([]*models.Ban, error) {
+	ctx, span := otel.Tracer.Start(ctx, "bansRepo.ListActive")
+	defer span.End()
+
+	list := make([]*models.Ban, 0)
+	if err := r.db.SelectContext(ctx, &list, listActiveBans); err != nil {
+		return nil, errors.Wrap(err, "bansRepo.ListActive.SelectContext")
+	}
+
+	return list, nil
+}
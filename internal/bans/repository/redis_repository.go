@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/bans"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+)
+
+// Bans redis repository
+type bansRedisRepo struct {
+	redisClient *redis.Client
+	cfg         *config.Config
+}
+
+// NewBansRedisRepo Bans redis repository constructor
+func NewBansRedisRepo(redisClient *redis.Client, cfg *config.Config) bans.RedisRepository {
+	return &bansRedisRepo{redisClient: redisClient, cfg: cfg}
+}
+
+// PublishChange Tell every instance to reload the ban list
+func (r *bansRedisRepo) PublishChange(ctx context.Context) error {
+	ctx, span := otel.Tracer.Start(ctx, "bansRedisRepo.PublishChange")
+	defer span.End()
+
+	if err := r.redisClient.Publish(ctx, r.cfg.Bans.Channel, "reload").Err(); err != nil {
+		return errors.Wrap(err, "bansRedisRepo.PublishChange.Publish")
+	}
+	return nil
+}
+
+// SubscribeChanges Listen for ban list changes, the subscription survives reconnects and ends with ctx
+func (r *bansRedisRepo) SubscribeChanges(ctx context.Context) (<-chan struct{}, error) {
+	pubsub := r.redisClient.Subscribe(ctx, r.cfg.Bans.Channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, errors.Wrap(err, "bansRedisRepo.SubscribeChanges.Receive")
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer close(changes)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-messages:
+				if !ok {
+					return
+				}
+				// A reload reads everything, so changes arriving meanwhile collapse into one
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return changes, nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+const (
+	createBan = `INSERT INTO bans (type, value, reason, banned_by, expires_at) VALUES (?, ?, ?, ?, ?)`
+
+	updateBan = `UPDATE bans SET type = ?, value = ?, reason = ?, banned_by = ?, expires_at = ? WHERE id = ?`
+
+	deleteBan = `DELETE FROM bans WHERE id = ?`
+
+	getBanByID = `SELECT id, type, value, reason, banned_by, expires_at, created_at, updated_at FROM bans WHERE id = ?`
+
+	getBanByValue = `SELECT id, type, value, reason, banned_by, expires_at, created_at, updated_at FROM bans WHERE type = ? AND value = ?`
+
+	listBans = `SELECT id, type, value, reason, banned_by, expires_at, created_at, updated_at
+		FROM bans ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+
+	countBans = `SELECT COUNT(*) FROM bans`
+
+	listActiveBans = `SELECT id, type, value, reason, banned_by, expires_at, created_at, updated_at
+		FROM bans WHERE expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP`
+)
@@ -0,0 +1,26 @@
+package bans
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// UseCase Bans UseCase interface. Checks run against an in-memory copy of the active bans,
+// it is rebuilt by Reload and kept in sync across instances by Watch.
+type UseCase interface {
+	// Check returns a 403 RestError explaining the ban when the ip address is banned
+	Check(ctx context.Context, ip string) error
+	Create(ctx context.Context, actorID uuid.UUID, input *models.BanRequest) (*models.Ban, error)
+	Update(ctx context.Context, actorID uuid.UUID, banID int64, input *models.BanRequest) (*models.Ban, error)
+	Delete(ctx context.Context, actorID uuid.UUID, banID int64) error
+	GetByID(ctx context.Context, banID int64) (*models.Ban, error)
+	List(ctx context.Context, pq *utils.PaginationQuery) (*models.BanList, error)
+	// Reload rebuilds the in-memory ban list from MySQL
+	Reload(ctx context.Context) error
+	// Watch reloads the ban list on every published change and on the refresh interval, until ctx is done
+	Watch(ctx context.Context)
+}
@@ -0,0 +1,372 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/audit"
+	"github.com/iamaul/go-evonix-backend-api/internal/bans"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/clientip"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/geoip"
+	"github.com/iamaul/go-evonix-backend-api/pkg/iptree"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	maxPageSize            = 100
+	defaultRefreshInterval = time.Minute * 5
+)
+
+// Bans UseCase
+type bansUC struct {
+	cfg       *config.Config
+	bansRepo  bans.Repository
+	redisRepo bans.RedisRepository
+	auditUC   audit.UseCase
+	geo       *geoip.Reader
+	logger    logger.Logger
+
+	// list holds the *banList the requests are checked against
+	list atomic.Value
+}
+
+// banList is the compiled form of the active bans
+type banList struct {
+	networks *iptree.Tree
+	asns     map[uint][]*models.Ban
+}
+
+// NewBansUseCase Bans UseCase constructor, the ban list is empty until the first Reload
+func NewBansUseCase(
+	cfg *config.Config,
+	bansRepo bans.Repository,
+	redisRepo bans.RedisRepository,
+	auditUC audit.UseCase,
+	geo *geoip.Reader,
+	log logger.Logger,
+) bans.UseCase {
+	u := &bansUC{cfg: cfg, bansRepo: bansRepo, redisRepo: redisRepo, auditUC: auditUC, geo: geo, logger: log}
+	u.list.Store(&banList{networks: iptree.New(), asns: map[uint][]*models.Ban{}})
+	return u
+}
+
+// Check Reject a banned ip address, networks are checked most specific first and then the ASN
+func (u *bansUC) Check(ctx context.Context, ip string) error {
+	ctx, span := otel.Tracer.Start(ctx, "bansUC.Check")
+	defer span.End()
+
+	addr := clientip.ParseIP(ip)
+	if addr == nil {
+		return nil
+	}
+	list := u.list.Load().(*banList)
+	now := time.Now()
+
+	for _, value := range list.networks.Lookup(addr) {
+		if ban := value.(*models.Ban); ban.Active(now) {
+			return u.banError(ban)
+		}
+	}
+
+	if len(list.asns) == 0 {
+		return nil
+	}
+	asn, ok, err := u.geo.ASN(addr)
+	if err != nil {
+		u.logger.Errorf("bansUC.Check.ASN, IP: %s, Error: %s", ip, err)
+		return nil
+	}
+	if ok {
+		for _, ban := range list.asns[asn.Number] {
+			if ban.Active(now) {
+				return u.banError(ban)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Create Ban an address, a network or an ASN
+func (u *bansUC) Create(ctx context.Context, actorID uuid.UUID, input *models.BanRequest) (*models.Ban, error) {
+	ctx, span := otel.Tracer.Start(ctx, "bansUC.Create")
+	defer span.End()
+
+	ban, err := banFromRequest(input)
+	if err != nil {
+		return nil, err
+	}
+	ban.BannedBy = &actorID
+
+	// An expired ban keeps its row and the unique key, banning again replaces it
+	expired, err := u.bansRepo.GetByValue(ctx, ban.Type, ban.Value)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if expired != nil && expired.Active(time.Now()) {
+		expired = nil
+	}
+
+	var created *models.Ban
+	if expired != nil {
+		ban.ID = expired.ID
+		created, err = u.bansRepo.Update(ctx, ban)
+	} else {
+		created, err = u.bansRepo.Create(ctx, ban)
+	}
+	if err != nil {
+		return nil, duplicateBanError(err)
+	}
+
+	change := models.AuditChange{New: created}
+	if expired != nil {
+		change.Old = expired
+	}
+	u.auditUC.Record(ctx, &models.AuditLog{Action: models.AuditBanCreated, ActorID: &actorID, Changes: models.AuditChanges{"ban": change}})
+	u.changed(ctx)
+	return created, nil
+}
+
+// Update Replace a ban, whoever changes it becomes the one who banned
+func (u *bansUC) Update(ctx context.Context, actorID uuid.UUID, banID int64, input *models.BanRequest) (*models.Ban, error) {
+	ctx, span := otel.Tracer.Start(ctx, "bansUC.Update")
+	defer span.End()
+
+	old, err := u.bansRepo.GetByID(ctx, banID)
+	if err != nil {
+		return nil, err
+	}
+	ban, err := banFromRequest(input)
+	if err != nil {
+		return nil, err
+	}
+	ban.ID, ban.BannedBy = banID, &actorID
+
+	updated, err := u.bansRepo.Update(ctx, ban)
+	if err != nil {
+		return nil, duplicateBanError(err)
+	}
+
+	u.auditUC.Record(ctx, &models.AuditLog{Action: models.AuditBanUpdated, ActorID: &actorID, Changes: models.AuditChanges{"ban": {Old: old, New: updated}}})
+	u.changed(ctx)
+	return updated, nil
+}
+
+// Delete Lift a ban
+func (u *bansUC) Delete(ctx context.Context, actorID uuid.UUID, banID int64) error {
+	ctx, span := otel.Tracer.Start(ctx, "bansUC.Delete")
+	defer span.End()
+
+	old, err := u.bansRepo.GetByID(ctx, banID)
+	if err != nil {
+		return err
+	}
+	if err = u.bansRepo.Delete(ctx, banID); err != nil {
+		return err
+	}
+
+	u.auditUC.Record(ctx, &models.AuditLog{Action: models.AuditBanDeleted, ActorID: &actorID, Changes: models.AuditChanges{"ban": {Old: old}}})
+	u.changed(ctx)
+	return nil
+}
+
+// GetByID Get a ban by id
+func (u *bansUC) GetByID(ctx context.Context, banID int64) (*models.Ban, error) {
+	ctx, span := otel.Tracer.Start(ctx, "bansUC.GetByID")
+	defer span.End()
+
+	return u.bansRepo.GetByID(ctx, banID)
+}
+
+// List Get a page of bans, expired ones included
+func (u *bansUC) List(ctx context.Context, pq *utils.PaginationQuery) (*models.BanList, error) {
+	ctx, span := otel.Tracer.Start(ctx, "bansUC.List")
+	defer span.End()
+
+	if pq.GetSize() <= 0 || pq.GetSize() > maxPageSize {
+		pq.Size = maxPageSize
+	}
+
+	list, totalCount, err := u.bansRepo.List(ctx, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BanList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Bans:       list,
+	}, nil
+}
+
+// Reload Rebuild the in-memory ban list from the active bans
+func (u *bansUC) Reload(ctx context.Context) error {
+	ctx, span := otel.Tracer.Start(ctx, "bansUC.Reload")
+	defer span.End()
+
+	active, err := u.bansRepo.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	list := &banList{networks: iptree.New(), asns: map[uint][]*models.Ban{}}
+	for _, ban := range active {
+		switch ban.Type {
+		case models.BanTypeIP, models.BanTypeCIDR:
+			network, err := parseNetwork(ban.Type, ban.Value)
+			if err != nil {
+				u.logger.Errorf("bansUC.Reload.parseNetwork, BanID: %d, Error: %s", ban.ID, err)
+				continue
+			}
+			list.networks.Insert(network, ban)
+		case models.BanTypeASN:
+			asn, err := strconv.ParseUint(ban.Value, 10, 32)
+			if err != nil {
+				u.logger.Errorf("bansUC.Reload.ParseUint, BanID: %d, Error: %s", ban.ID, err)
+				continue
+			}
+			list.asns[uint(asn)] = append(list.asns[uint(asn)], ban)
+		}
+	}
+	if len(list.asns) > 0 && !u.geo.HasASN() {
+		u.logger.Warnf("bansUC.Reload, %d ASN bans are not enforced without an ASN database", len(list.asns))
+	}
+
+	u.list.Store(list)
+	u.logger.Debugf("bansUC.Reload, %d active bans loaded", len(active))
+	return nil
+}
+
+// Watch Reload the ban list whenever a change is published and on every refresh interval
+func (u *bansUC) Watch(ctx context.Context) {
+	// Without the subscription the refresh interval still picks changes up, only later
+	changes, err := u.redisRepo.SubscribeChanges(ctx)
+	if err != nil {
+		u.logger.Errorf("bansUC.Watch.SubscribeChanges, Error: %s", err)
+	}
+
+	interval := u.cfg.Bans.RefreshInterval
+	if interval <= 0 {
+		interval = defaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		if err := u.Reload(ctx); err != nil {
+			u.logger.Errorf("bansUC.Watch.Reload, Error: %s", err)
+		}
+	}
+}
+
+// changed reloads this instance right away and tells the others
+func (u *bansUC) changed(ctx context.Context) {
+	if err := u.Reload(ctx); err != nil {
+		u.logger.Errorf("bansUC.changed.Reload, Error: %s", err)
+	}
+	if err := u.redisRepo.PublishChange(ctx); err != nil {
+		u.logger.Errorf("bansUC.changed.PublishChange, Error: %s", err)
+	}
+}
+
+func (u *bansUC) banError(ban *models.Ban) error {
+	appeal := fmt.Sprintf("If you think this ban is a mistake, appeal it at %s and mention ban #%d", u.cfg.Bans.AppealURL, ban.ID)
+	return httpErr.NewRestError(http.StatusForbidden, httpErr.ErrBanned.Error(), map[string]interface{}{
+		"ban_id":     ban.ID,
+		"reason":     ban.Reason,
+		"expires_at": ban.ExpiresAt,
+		"appeal_url": u.cfg.Bans.AppealURL,
+		"message":    appeal,
+	})
+}
+
+// banFromRequest validates the ban and normalizes its value
+func banFromRequest(input *models.BanRequest) (*models.Ban, error) {
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, httpErr.NewBadRequestError(map[string]string{"expires_at": "must be in the future"})
+	}
+
+	ban := &models.Ban{Type: input.Type, Reason: strings.TrimSpace(input.Reason), ExpiresAt: input.ExpiresAt}
+	switch input.Type {
+	case models.BanTypeIP, models.BanTypeCIDR:
+		network, err := parseNetwork(input.Type, input.Value)
+		if err != nil {
+			return nil, httpErr.NewBadRequestError(map[string]string{"value": err.Error()})
+		}
+		ban.Value = network.IP.String()
+		if input.Type == models.BanTypeCIDR {
+			ban.Value = network.String()
+		}
+	case models.BanTypeASN:
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(input.Value)), "AS"), 10, 32)
+		if err != nil || asn == 0 {
+			return nil, httpErr.NewBadRequestError(map[string]string{"value": "must be an AS number"})
+		}
+		ban.Value = strconv.FormatUint(asn, 10)
+	default:
+		return nil, httpErr.NewBadRequestError(map[string]string{"type": "must be one of ip, cidr, asn"})
+	}
+
+	return ban, nil
+}
+
+// parseNetwork turns an ip ban into a single address network and masks the host bits of a cidr ban
+func parseNetwork(banType, value string) (*net.IPNet, error) {
+	if banType == models.BanTypeIP {
+		ip := clientip.ParseIP(value)
+		if ip == nil {
+			return nil, errors.New("must be an IP address")
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}, nil
+	}
+
+	ip, network, err := net.ParseCIDR(strings.TrimSpace(value))
+	if err != nil {
+		return nil, errors.New("must be a network in CIDR notation")
+	}
+	ones, bits := network.Mask.Size()
+	if ip.To4() != nil && bits != 32 {
+		return nil, errors.New("must use IPv4 notation for IPv4 networks")
+	}
+	if ones == 0 {
+		return nil, errors.New("would ban every address")
+	}
+	return network, nil
+}
+
+// duplicateBanError turns a unique key violation into a 409
+func duplicateBanError(err error) error {
+	if httpErr.IsDuplicateEntry(err) {
+		return httpErr.NewRestError(http.StatusConflict, "ban already exists", map[string]string{"value": "is already banned"})
+	}
+	return err
+}
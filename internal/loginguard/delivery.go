@@ -0,0 +1,9 @@
+package loginguard
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Login guard HTTP Handlers interface
+type Handlers interface {
+	ClearUser() echo.HandlerFunc
+	ClearIP() echo.HandlerFunc
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/loginguard"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Login guard handlers
+type loginGuardHandlers struct {
+	cfg          *config.Config
+	loginGuardUC loginguard.UseCase
+	logger       logger.Logger
+}
+
+// NewLoginGuardHandlers Login guard handlers constructor
+func NewLoginGuardHandlers(cfg *config.Config, loginGuardUC loginguard.UseCase, log logger.Logger) loginguard.Handlers {
+	return &loginGuardHandlers{cfg: cfg, loginGuardUC: loginGuardUC, logger: log}
+}
+
+// ClearUser Lift the lockout of a user
+func (h *loginGuardHandlers) ClearUser() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "loginGuardHandlers.ClearUser")
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.loginGuardUC.ClearUser(ctx, userID); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// ClearIP Lift the lockout of an IP
+func (h *loginGuardHandlers) ClearIP() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "loginGuardHandlers.ClearIP")
+		defer span.End()
+
+		ip := net.ParseIP(c.Param("ip"))
+		if ip == nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErr.NewBadRequestError("invalid ip address"))
+		}
+
+		if err := h.loginGuardUC.ClearIP(ctx, ip.String()); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
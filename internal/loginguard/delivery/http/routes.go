@@ -0,0 +1,15 @@
+package http
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/internal/loginguard"
+	"github.com/iamaul/go-evonix-backend-api/internal/middleware"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MapLoginGuardAdminRoutes Map lockout routes of the staff tools
+func MapLoginGuardAdminRoutes(adminGroup *echo.Group, h loginguard.Handlers, mw *middleware.MiddlewareManager) {
+	adminGroup.DELETE("/users/:user_id/lockout", h.ClearUser(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionLockoutsWrite))
+	adminGroup.DELETE("/lockouts/ips/:ip", h.ClearIP(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionLockoutsWrite))
+}
@@ -0,0 +1,19 @@
+package loginguard
+
+import (
+	"context"
+	"time"
+)
+
+// RedisRepository Login guard Redis repository interface, keys name a scope such as "user:<id>" or "ip:<addr>"
+type RedisRepository interface {
+	// IncrFailures counts a failure, the counter expires window after the last failure
+	IncrFailures(ctx context.Context, key string, window time.Duration) (int64, error)
+	Lock(ctx context.Context, key string, duration time.Duration) error
+	// LockTTL returns the longest remaining lockout of the keys, zero when none is locked
+	LockTTL(ctx context.Context, keys ...string) (time.Duration, error)
+	// Reset clears failures and lockout of a key
+	Reset(ctx context.Context, key string) error
+	// AddFailedAccount records an account that failed from the IP key and returns how many distinct ones did
+	AddFailedAccount(ctx context.Context, ipKey, account string, window time.Duration) (int64, error)
+}
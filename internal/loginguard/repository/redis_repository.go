@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/loginguard"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+)
+
+// Login guard redis repository
+type loginGuardRedisRepo struct {
+	redisClient *redis.Client
+	cfg         *config.Config
+}
+
+// NewLoginGuardRedisRepo Login guard redis repository constructor
+func NewLoginGuardRedisRepo(redisClient *redis.Client, cfg *config.Config) loginguard.RedisRepository {
+	return &loginGuardRedisRepo{redisClient: redisClient, cfg: cfg}
+}
+
+// IncrFailures Count a failed login
+func (r *loginGuardRedisRepo) IncrFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	ctx, span := otel.Tracer.Start(ctx, "loginGuardRedisRepo.IncrFailures")
+	defer span.End()
+
+	pipe := r.redisClient.TxPipeline()
+	incr := pipe.Incr(ctx, r.failuresKey(key))
+	pipe.Expire(ctx, r.failuresKey(key), window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, errors.Wrap(err, "loginGuardRedisRepo.IncrFailures.Exec")
+	}
+	return incr.Val(), nil
+}
+
+// Lock Lock a key out for the duration
+func (r *loginGuardRedisRepo) Lock(ctx context.Context, key string, duration time.Duration) error {
+	ctx, span := otel.Tracer.Start(ctx, "loginGuardRedisRepo.Lock")
+	defer span.End()
+
+	if err := r.redisClient.Set(ctx, r.lockKey(key), 1, duration).Err(); err != nil {
+		return errors.Wrap(err, "loginGuardRedisRepo.Lock.Set")
+	}
+	return nil
+}
+
+// LockTTL Get the longest remaining lockout of the keys
+func (r *loginGuardRedisRepo) LockTTL(ctx context.Context, keys ...string) (time.Duration, error) {
+	ctx, span := otel.Tracer.Start(ctx, "loginGuardRedisRepo.LockTTL")
+	defer span.End()
+
+	pipe := r.redisClient.Pipeline()
+	cmds := make([]*redis.DurationCmd, 0, len(keys))
+	for _, key := range keys {
+		cmds = append(cmds, pipe.PTTL(ctx, r.lockKey(key)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, errors.Wrap(err, "loginGuardRedisRepo.LockTTL.Exec")
+	}
+
+	var longest time.Duration
+	for _, cmd := range cmds {
+		// Missing keys answer a negative ttl
+		if ttl := cmd.Val(); ttl > longest {
+			longest = ttl
+		}
+	}
+	return longest, nil
+}
+
+// Reset Clear failures and lockout of a key
+func (r *loginGuardRedisRepo) Reset(ctx context.Context, key string) error {
+	ctx, span := otel.Tracer.Start(ctx, "loginGuardRedisRepo.Reset")
+	defer span.End()
+
+	if err := r.redisClient.Del(ctx, r.failuresKey(key), r.lockKey(key), r.accountsKey(key)).Err(); err != nil {
+		return errors.Wrap(err, "loginGuardRedisRepo.Reset.Del")
+	}
+	return nil
+}
+
+// AddFailedAccount Record an account that failed to log in from the IP
+func (r *loginGuardRedisRepo) AddFailedAccount(ctx context.Context, ipKey, account string, window time.Duration) (int64, error) {
+	ctx, span := otel.Tracer.Start(ctx, "loginGuardRedisRepo.AddFailedAccount")
+	defer span.End()
+
+	key := r.accountsKey(ipKey)
+	pipe := r.redisClient.TxPipeline()
+	pipe.SAdd(ctx, key, account)
+	pipe.Expire(ctx, key, window)
+	card := pipe.SCard(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, errors.Wrap(err, "loginGuardRedisRepo.AddFailedAccount.Exec")
+	}
+	return card.Val(), nil
+}
+
+func (r *loginGuardRedisRepo) failuresKey(key string) string {
+	return r.cfg.LoginProtection.Prefix + ":failures:" + key
+}
+
+func (r *loginGuardRedisRepo) lockKey(key string) string {
+	return r.cfg.LoginProtection.Prefix + ":lock:" + key
+}
+
+func (r *loginGuardRedisRepo) accountsKey(key string) string {
+	return r.cfg.LoginProtection.Prefix + ":accounts:" + key
+}
@@ -0,0 +1,20 @@
+package loginguard
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// UseCase Login guard UseCase interface. An account is the user ID of an existing user or the
+// normalized login otherwise, so probing unknown accounts is throttled the same way.
+type UseCase interface {
+	// Check returns a 429 RestError with the remaining time while the account or the IP is locked out
+	Check(ctx context.Context, account, ip string) error
+	// RecordFailure counts a failed login and locks the account or the IP out past their threshold
+	RecordFailure(ctx context.Context, account, ip string) error
+	// RecordSuccess clears the failures of the account, those of the IP keep decaying
+	RecordSuccess(ctx context.Context, account string) error
+	ClearUser(ctx context.Context, userID uuid.UUID) error
+	ClearIP(ctx context.Context, ip string) error
+}
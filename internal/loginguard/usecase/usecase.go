@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/audit"
+	"github.com/iamaul/go-evonix-backend-api/internal/loginguard"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/metrics"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/google/uuid"
+)
+
+// Lockout scopes, also the metrics labels
+const (
+	scopeAccount = "account"
+	scopeIP      = "ip"
+
+	reasonManyAccountsPerIP = "many_accounts_per_ip"
+)
+
+// Login guard UseCase
+type loginGuardUC struct {
+	cfg       *config.Config
+	guardRepo loginguard.RedisRepository
+	auditUC   audit.UseCase
+	metrics   metrics.Metrics
+	logger    logger.Logger
+}
+
+// NewLoginGuardUseCase Login guard UseCase constructor
+func NewLoginGuardUseCase(cfg *config.Config, guardRepo loginguard.RedisRepository, auditUC audit.UseCase, metrics metrics.Metrics, log logger.Logger) loginguard.UseCase {
+	return &loginGuardUC{cfg: cfg, guardRepo: guardRepo, auditUC: auditUC, metrics: metrics, logger: log}
+}
+
+// Check Reject the login while the account or the IP is locked out
+func (u *loginGuardUC) Check(ctx context.Context, account, ip string) error {
+	ctx, span := otel.Tracer.Start(ctx, "loginGuardUC.Check")
+	defer span.End()
+
+	ttl, err := u.guardRepo.LockTTL(ctx, accountKey(account), ipKey(ip))
+	if err != nil {
+		return err
+	}
+	if ttl > 0 {
+		return lockedError(ttl)
+	}
+
+	return nil
+}
+
+// RecordFailure Count a failed login against the account and the IP
+func (u *loginGuardUC) RecordFailure(ctx context.Context, account, ip string) error {
+	ctx, span := otel.Tracer.Start(ctx, "loginGuardUC.RecordFailure")
+	defer span.End()
+
+	u.metrics.IncLoginFailures()
+	protection := u.cfg.LoginProtection
+
+	if err := u.recordFailure(ctx, scopeAccount, accountKey(account), protection.AccountThreshold); err != nil {
+		return err
+	}
+	if err := u.recordFailure(ctx, scopeIP, ipKey(ip), protection.IPThreshold); err != nil {
+		return err
+	}
+
+	accounts, err := u.guardRepo.AddFailedAccount(ctx, ipKey(ip), account, protection.FailureWindow)
+	if err != nil {
+		return err
+	}
+	// Report once when the IP crosses the limit rather than on every further failure
+	if protection.SuspiciousAccountsPerIP > 0 && accounts == int64(protection.SuspiciousAccountsPerIP) {
+		u.metrics.IncSuspiciousActivity(reasonManyAccountsPerIP)
+		u.logger.Warnf("loginGuardUC.RecordFailure, IP %s failed to log in to %d accounts within %s", ip, accounts, protection.FailureWindow)
+	}
+
+	return nil
+}
+
+// RecordSuccess Clear the failures of the account
+func (u *loginGuardUC) RecordSuccess(ctx context.Context, account string) error {
+	ctx, span := otel.Tracer.Start(ctx, "loginGuardUC.RecordSuccess")
+	defer span.End()
+
+	return u.guardRepo.Reset(ctx, accountKey(account))
+}
+
+// ClearUser Lift the lockout of a user
+func (u *loginGuardUC) ClearUser(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := otel.Tracer.Start(ctx, "loginGuardUC.ClearUser")
+	defer span.End()
+
+	if err := u.guardRepo.Reset(ctx, accountKey(userID.String())); err != nil {
+		return err
+	}
+
+	u.auditUC.Record(ctx, &models.AuditLog{Action: models.AuditLockoutCleared, TargetID: &userID})
+	return nil
+}
+
+// ClearIP Lift the lockout of an IP
+func (u *loginGuardUC) ClearIP(ctx context.Context, ip string) error {
+	ctx, span := otel.Tracer.Start(ctx, "loginGuardUC.ClearIP")
+	defer span.End()
+
+	if err := u.guardRepo.Reset(ctx, ipKey(ip)); err != nil {
+		return err
+	}
+
+	u.auditUC.Record(ctx, &models.AuditLog{Action: models.AuditIPLockoutCleared, Changes: models.AuditChanges{"locked_ip": {Old: ip}}})
+	return nil
+}
+
+func (u *loginGuardUC) recordFailure(ctx context.Context, scope, key string, threshold int) error {
+	failures, err := u.guardRepo.IncrFailures(ctx, key, u.cfg.LoginProtection.FailureWindow)
+	if err != nil {
+		return err
+	}
+	if threshold <= 0 || failures < int64(threshold) {
+		return nil
+	}
+
+	u.metrics.IncLockouts(scope)
+	return u.guardRepo.Lock(ctx, key, u.lockout(failures-int64(threshold)))
+}
+
+// lockout doubles the base lockout for every failure past the threshold
+func (u *loginGuardUC) lockout(excess int64) time.Duration {
+	protection := u.cfg.LoginProtection
+	duration := protection.BaseLockout
+	for i := int64(0); i < excess && duration < protection.MaxLockout; i++ {
+		duration *= 2
+	}
+	if protection.MaxLockout > 0 && duration > protection.MaxLockout {
+		duration = protection.MaxLockout
+	}
+	return duration
+}
+
+func accountKey(account string) string {
+	return scopeAccount + ":" + account
+}
+
+func ipKey(ip string) string {
+	return scopeIP + ":" + ip
+}
+
+func lockedError(ttl time.Duration) error {
+	return httpErr.NewTooManyRequestsError(httpErr.ErrTooManyLoginAttempts.Error(), ttl, map[string]int{
+		"retry_after": int((ttl + time.Second - 1) / time.Second),
+	})
+}
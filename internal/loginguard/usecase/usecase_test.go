@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/metrics"
+)
+
+// memoryGuardRepo keeps failures and lockouts in maps, time stands still so a lockout keeps its full duration
+type memoryGuardRepo struct {
+	failures map[string]int64
+	locks    map[string]time.Duration
+	accounts map[string]map[string]bool
+}
+
+func newMemoryGuardRepo() *memoryGuardRepo {
+	return &memoryGuardRepo{
+		failures: make(map[string]int64),
+		locks:    make(map[string]time.Duration),
+		accounts: make(map[string]map[string]bool),
+	}
+}
+
+func (r *memoryGuardRepo) IncrFailures(_ context.Context, key string, _ time.Duration) (int64, error) {
+	r.failures[key]++
+	return r.failures[key], nil
+}
+
+func (r *memoryGuardRepo) Lock(_ context.Context, key string, duration time.Duration) error {
+	r.locks[key] = duration
+	return nil
+}
+
+func (r *memoryGuardRepo) LockTTL(_ context.Context, keys ...string) (time.Duration, error) {
+	var longest time.Duration
+	for _, key := range keys {
+		if r.locks[key] > longest {
+			longest = r.locks[key]
+		}
+	}
+	return longest, nil
+}
+
+func (r *memoryGuardRepo) Reset(_ context.Context, key string) error {
+	delete(r.failures, key)
+	delete(r.locks, key)
+	return nil
+}
+
+func (r *memoryGuardRepo) AddFailedAccount(_ context.Context, ipKey, account string, _ time.Duration) (int64, error) {
+	if r.accounts[ipKey] == nil {
+		r.accounts[ipKey] = make(map[string]bool)
+	}
+	r.accounts[ipKey][account] = true
+	return int64(len(r.accounts[ipKey])), nil
+}
+
+type nopMetrics struct {
+	metrics.Metrics
+}
+
+func (nopMetrics) IncLoginFailures()            {}
+func (nopMetrics) IncLockouts(string)           {}
+func (nopMetrics) IncSuspiciousActivity(string) {}
+
+// retryAfter returns how long Check keeps the login locked out, zero when it is allowed
+func retryAfter(t *testing.T, u *loginGuardUC, account, ip string) time.Duration {
+	t.Helper()
+
+	err := u.Check(context.Background(), account, ip)
+	if err == nil {
+		return 0
+	}
+	var tooMany httpErr.TooManyRequestsError
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("Check() error = %v, want a TooManyRequestsError", err)
+	}
+	return tooMany.RetryAfter
+}
+
+func TestRecordFailureLocksOutProgressively(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.LoginProtection = config.LoginProtection{
+		AccountThreshold: 3,
+		IPThreshold:      5,
+		FailureWindow:    15 * time.Minute,
+		BaseLockout:      time.Minute,
+		MaxLockout:       8 * time.Minute,
+	}
+	u := &loginGuardUC{cfg: cfg, guardRepo: newMemoryGuardRepo(), metrics: nopMetrics{}}
+
+	// The account and the IP count separately, the longest lockout wins
+	tests := []struct {
+		failures    int
+		wantAccount time.Duration
+		wantIP      time.Duration
+	}{
+		{1, 0, 0},
+		{2, 0, 0},
+		{3, time.Minute, 0},
+		{4, 2 * time.Minute, 0},
+		{5, 4 * time.Minute, time.Minute},
+		{6, 8 * time.Minute, 2 * time.Minute},
+		{7, 8 * time.Minute, 4 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if err := u.RecordFailure(context.Background(), "alice", "198.51.100.7"); err != nil {
+			t.Fatal(err)
+		}
+		if got := retryAfter(t, u, "alice", "198.51.100.7"); got != tt.wantAccount {
+			t.Errorf("after %d failures the account is locked for %s, want %s", tt.failures, got, tt.wantAccount)
+		}
+		if got := retryAfter(t, u, "bob", "198.51.100.7"); got != tt.wantIP {
+			t.Errorf("after %d failures the IP is locked for %s, want %s", tt.failures, got, tt.wantIP)
+		}
+	}
+
+	if got := retryAfter(t, u, "alice", "203.0.113.9"); got != 8*time.Minute {
+		t.Errorf("the account lockout holds from another IP for %s, want %s", got, 8*time.Minute)
+	}
+	// A success clears the account, the IP stays locked for every account
+	if err := u.RecordSuccess(context.Background(), "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if got := retryAfter(t, u, "alice", "203.0.113.9"); got != 0 {
+		t.Errorf("the account is still locked for %s after a success", got)
+	}
+	if got := retryAfter(t, u, "alice", "198.51.100.7"); got != 4*time.Minute {
+		t.Errorf("the IP lockout holds after a success for %s, want %s", got, 4*time.Minute)
+	}
+}
@@ -0,0 +1,9 @@
+package loginhistory
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Login history HTTP Handlers interface
+type Handlers interface {
+	ListMine() echo.HandlerFunc
+	List() echo.HandlerFunc
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/loginhistory"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/clientip"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Login history handlers
+type loginHistoryHandlers struct {
+	cfg            *config.Config
+	loginHistoryUC loginhistory.UseCase
+	logger         logger.Logger
+}
+
+// NewLoginHistoryHandlers Login history handlers constructor
+func NewLoginHistoryHandlers(cfg *config.Config, loginHistoryUC loginhistory.UseCase, log logger.Logger) loginhistory.Handlers {
+	return &loginHistoryHandlers{cfg: cfg, loginHistoryUC: loginHistoryUC, logger: log}
+}
+
+// ListMine Get the login history of the current user
+func (h *loginHistoryHandlers) ListMine() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "loginHistoryHandlers.ListMine")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErr.NewBadRequestError(httpErr.ErrBadQueryParams.Error()))
+		}
+
+		logins, err := h.loginHistoryUC.ListMine(ctx, principal.UserID, pq)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: logins, Success: true})
+	}
+}
+
+// List Get the login history filtered by user_id, result, ip and asn, for staff
+func (h *loginHistoryHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "loginHistoryHandlers.List")
+		defer span.End()
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErr.NewBadRequestError(httpErr.ErrBadQueryParams.Error()))
+		}
+		filter, err := filterFromQuery(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		logins, err := h.loginHistoryUC.List(ctx, filter, pq)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: logins, Success: true})
+	}
+}
+
+func filterFromQuery(c echo.Context) (*models.LoginRecordFilter, error) {
+	filter := &models.LoginRecordFilter{Result: c.QueryParam("result")}
+
+	if value := c.QueryParam("user_id"); value != "" {
+		userID, err := uuid.Parse(value)
+		if err != nil {
+			return nil, httpErr.NewBadRequestError(map[string]string{"user_id": "must be a UUID"})
+		}
+		filter.UserID = &userID
+	}
+	if value := c.QueryParam("ip"); value != "" {
+		if filter.IP = clientip.Normalize(value); filter.IP == "" {
+			return nil, httpErr.NewBadRequestError(map[string]string{"ip": "must be an IP address"})
+		}
+	}
+	if value := c.QueryParam("asn"); value != "" {
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(value), "AS"), 10, 32)
+		if err != nil {
+			return nil, httpErr.NewBadRequestError(map[string]string{"asn": "must be an AS number"})
+		}
+		number := uint(asn)
+		filter.ASN = &number
+	}
+
+	return filter, nil
+}
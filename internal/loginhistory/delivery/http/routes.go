@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/internal/loginhistory"
+	"github.com/iamaul/go-evonix-backend-api/internal/middleware"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MapLoginHistoryRoutes Map the login history route of the account settings
+func MapLoginHistoryRoutes(accountGroup *echo.Group, h loginhistory.Handlers, mw *middleware.MiddlewareManager) {
+	accountGroup.GET("/logins", h.ListMine(), mw.AuthMiddleware)
+}
+
+// MapLoginHistoryAdminRoutes Map the login history route of the staff tools
+func MapLoginHistoryAdminRoutes(adminGroup *echo.Group, h loginhistory.Handlers, mw *middleware.MiddlewareManager) {
+	adminGroup.GET("/logins", h.List(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionUsersRead))
+}
@@ -0,0 +1,19 @@
+package loginhistory
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// Repository Login history MySQL repository interface
+type Repository interface {
+	Create(ctx context.Context, record *models.LoginRecord) error
+	// List returns a page of login records, newest first, and the total count
+	List(ctx context.Context, filter *models.LoginRecordFilter, pq *utils.PaginationQuery) ([]*models.LoginRecord, int, error)
+	// CountSucceeded returns how many successful logins the user has, and how many of them came from the country
+	CountSucceeded(ctx context.Context, userID uuid.UUID, countryCode string) (total int, fromCountry int, err error)
+}
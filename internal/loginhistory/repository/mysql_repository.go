@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/loginhistory"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Login history Repository
+type loginHistoryRepo struct {
+	db *sqlx.DB
+}
+
+// NewLoginHistoryRepository Login history Repository constructor
+func NewLoginHistoryRepository(db *sqlx.DB) loginhistory.Repository {
+	return &loginHistoryRepo{db: db}
+}
+
+// Create Save a login record
+func (r *loginHistoryRepo) Create(ctx context.Context, record *models.LoginRecord) error {
+	ctx, span := otel.Tracer.Start(ctx, "loginHistoryRepo.Create")
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, createLoginRecord,
+		record.UserID,
+		record.Result,
+		record.IP,
+		record.UserAgent,
+		record.CountryCode,
+		record.Country,
+		record.City,
+		record.ASN,
+		record.ASOrganization,
+	); err != nil {
+		return errors.Wrap(err, "loginHistoryRepo.Create.ExecContext")
+	}
+
+	return nil
+}
+
+// List Get a page of login records
+func (r *loginHistoryRepo) List(ctx context.Context, filter *models.LoginRecordFilter, pq *utils.PaginationQuery) ([]*models.LoginRecord, int, error) {
+	ctx, span := otel.Tracer.Start(ctx, "loginHistoryRepo.List")
+	defer span.End()
+
+	where, args := loginRecordConditions(filter)
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, countLoginRecords+where, args...); err != nil {
+		return nil, 0, errors.Wrap(err, "loginHistoryRepo.List.GetContext")
+	}
+
+	records := make([]*models.LoginRecord, 0, pq.GetLimit())
+	if totalCount == 0 {
+		return records, 0, nil
+	}
+
+	query := listLoginRecords + where + ` ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+	if err := r.db.SelectContext(ctx, &records, query, append(args, pq.GetLimit(), pq.GetOffset())...); err != nil {
+		return nil, 0, errors.Wrap(err, "loginHistoryRepo.List.SelectContext")
+	}
+
+	return records, totalCount, nil
+}
+
+// CountSucceeded Count the successful logins of a user, overall and from a country
+func (r *loginHistoryRepo) CountSucceeded(ctx context.Context, userID uuid.UUID, countryCode string) (int, int, error) {
+	ctx, span := otel.Tracer.Start(ctx, "loginHistoryRepo.CountSucceeded")
+	defer span.End()
+
+	var counts struct {
+		Total       int `db:"total"`
+		FromCountry int `db:"from_country"`
+	}
+	if err := r.db.GetContext(ctx, &counts, countSucceededLogins, countryCode, userID); err != nil {
+		return 0, 0, errors.Wrap(err, "loginHistoryRepo.CountSucceeded.GetContext")
+	}
+
+	return counts.Total, counts.FromCountry, nil
+}
+
+func loginRecordConditions(filter *models.LoginRecordFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.UserID != nil {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if filter.Result != "" {
+		conditions = append(conditions, "result = ?")
+		args = append(args, filter.Result)
+	}
+	if filter.IP != "" {
+		conditions = append(conditions, "ip = ?")
+		args = append(args, filter.IP)
+	}
+	if filter.ASN != nil {
+		conditions = append(conditions, "asn = ?")
+		args = append(args, filter.ASN)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,16 @@
+package repository
+
+const (
+	createLoginRecord = `INSERT INTO login_history
+		(user_id, result, ip, user_agent, country_code, country, city, asn, as_organization)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	// The filters are appended by List
+	listLoginRecords = `SELECT id, user_id, result, ip, user_agent, country_code, country, city, asn, as_organization, created_at
+		FROM login_history`
+
+	countLoginRecords = `SELECT COUNT(*) FROM login_history`
+
+	countSucceededLogins = `SELECT COUNT(*) AS total, COALESCE(SUM(country_code = ?), 0) AS from_country
+		FROM login_history WHERE user_id = ? AND result = 'succeeded'`
+)
@@ -0,0 +1,20 @@
+package loginhistory
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// UseCase Login history UseCase interface
+type UseCase interface {
+	// Record saves a login attempt with the ip, user agent and GeoIP data of the request.
+	// It never fails the login, errors are logged and the record is returned anyway.
+	Record(ctx context.Context, userID uuid.UUID, result string) *models.LoginRecord
+	// ListMine returns the login history of a player, without the ASN
+	ListMine(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.LoginRecordList, error)
+	List(ctx context.Context, filter *models.LoginRecordFilter, pq *utils.PaginationQuery) (*models.LoginRecordList, error)
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/loginhistory"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/clientip"
+	"github.com/iamaul/go-evonix-backend-api/pkg/geoip"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxPageSize     = 100
+	maxUserAgentLen = 512
+)
+
+// Login history UseCase
+type loginHistoryUC struct {
+	cfg              *config.Config
+	loginHistoryRepo loginhistory.Repository
+	geo              *geoip.Reader
+	logger           logger.Logger
+}
+
+// NewLoginHistoryUseCase Login history UseCase constructor
+func NewLoginHistoryUseCase(cfg *config.Config, loginHistoryRepo loginhistory.Repository, geo *geoip.Reader, log logger.Logger) loginhistory.UseCase {
+	return &loginHistoryUC{cfg: cfg, loginHistoryRepo: loginHistoryRepo, geo: geo, logger: log}
+}
+
+// Record Save a login attempt with where it came from
+func (u *loginHistoryUC) Record(ctx context.Context, userID uuid.UUID, result string) *models.LoginRecord {
+	ctx, span := otel.Tracer.Start(ctx, "loginHistoryUC.Record")
+	defer span.End()
+
+	meta := utils.GetRequestMetaFromCtx(ctx)
+	record := &models.LoginRecord{UserID: userID, Result: result, IP: meta.IP, UserAgent: meta.UserAgent}
+	if len(record.UserAgent) > maxUserAgentLen {
+		record.UserAgent = record.UserAgent[:maxUserAgentLen]
+	}
+	u.locate(record)
+
+	if result == models.LoginRecordSucceeded && record.CountryCode != "" {
+		total, fromCountry, err := u.loginHistoryRepo.CountSucceeded(ctx, userID, record.CountryCode)
+		if err != nil {
+			u.logger.Errorf("loginHistoryUC.Record.CountSucceeded, UserID: %s, Error: %s", userID, err)
+		}
+		// The first login of an account has nothing to compare with
+		record.NewCountry = err == nil && total > 0 && fromCountry == 0
+	}
+
+	if err := u.loginHistoryRepo.Create(ctx, record); err != nil {
+		u.logger.Errorf("loginHistoryUC.Record.Create, UserID: %s, Result: %s, Error: %s", userID, result, err)
+	}
+	return record
+}
+
+// ListMine Get the login history of a player
+func (u *loginHistoryUC) ListMine(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.LoginRecordList, error) {
+	ctx, span := otel.Tracer.Start(ctx, "loginHistoryUC.ListMine")
+	defer span.End()
+
+	list, err := u.List(ctx, &models.LoginRecordFilter{UserID: &userID}, pq)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range list.Logins {
+		record.ASN, record.ASOrganization = nil, ""
+	}
+
+	return list, nil
+}
+
+// List Get a page of the login history
+func (u *loginHistoryUC) List(ctx context.Context, filter *models.LoginRecordFilter, pq *utils.PaginationQuery) (*models.LoginRecordList, error) {
+	ctx, span := otel.Tracer.Start(ctx, "loginHistoryUC.List")
+	defer span.End()
+
+	if pq.GetSize() <= 0 || pq.GetSize() > maxPageSize {
+		pq.Size = maxPageSize
+	}
+
+	records, totalCount, err := u.loginHistoryRepo.List(ctx, filter, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.LoginRecordList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Logins:     records,
+	}, nil
+}
+
+// locate fills in the GeoIP data, a failed lookup only leaves it empty
+func (u *loginHistoryUC) locate(record *models.LoginRecord) {
+	ip := clientip.ParseIP(record.IP)
+	if ip == nil {
+		return
+	}
+
+	city, ok, err := u.geo.City(ip)
+	if err != nil {
+		u.logger.Errorf("loginHistoryUC.locate.City, IP: %s, Error: %s", record.IP, err)
+	} else if ok {
+		record.CountryCode, record.Country, record.City = city.CountryCode, city.Country, city.City
+	}
+
+	asn, ok, err := u.geo.ASN(ip)
+	if err != nil {
+		u.logger.Errorf("loginHistoryUC.locate.ASN, IP: %s, Error: %s", record.IP, err)
+	} else if ok {
+		record.ASN, record.ASOrganization = &asn.Number, asn.Organization
+	}
+}
@@ -0,0 +1,9 @@
+package mail
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Mail HTTP Handlers interface
+type Handlers interface {
+	ListTemplates() echo.HandlerFunc
+	Preview() echo.HandlerFunc
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/mail"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/mailer"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Mail handlers
+type mailHandlers struct {
+	cfg    *config.Config
+	mailUC mail.UseCase
+	logger logger.Logger
+}
+
+// NewMailHandlers Mail handlers constructor
+func NewMailHandlers(cfg *config.Config, mailUC mail.UseCase, log logger.Logger) mail.Handlers {
+	return &mailHandlers{cfg: cfg, mailUC: mailUC, logger: log}
+}
+
+// ListTemplates List the templates that can be previewed
+func (h *mailHandlers) ListTemplates() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: mailer.TemplateNames(), Success: true})
+	}
+}
+
+// Preview Render a template with sample data, ?lang=id|en picks the language and
+// ?format=html|text answers with that part alone so it can be opened in a browser
+func (h *mailHandlers) Preview() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "mailHandlers.Preview")
+		defer span.End()
+
+		msg, err := h.mailUC.Preview(ctx, c.Param("template"), c.QueryParam("lang"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		switch c.QueryParam("format") {
+		case "html":
+			return c.HTML(http.StatusOK, msg.HTML)
+		case "text":
+			return c.String(http.StatusOK, msg.Text)
+		default:
+			return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: msg, Success: true})
+		}
+	}
+}
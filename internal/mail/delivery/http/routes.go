@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/internal/mail"
+	"github.com/iamaul/go-evonix-backend-api/internal/middleware"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MapMailAdminRoutes Map mail template routes of the staff tools
+func MapMailAdminRoutes(mailGroup *echo.Group, h mail.Handlers, mw *middleware.MiddlewareManager) {
+	mailGroup.Use(mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionMailPreview))
+	mailGroup.GET("/templates", h.ListTemplates())
+	mailGroup.GET("/templates/:template/preview", h.Preview())
+}
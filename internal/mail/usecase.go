@@ -0,0 +1,13 @@
+package mail
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/pkg/mailer"
+)
+
+// UseCase Mail UseCase interface
+type UseCase interface {
+	// Preview renders a template with sample data, nothing is sent
+	Preview(ctx context.Context, template, lang string) (*mailer.Message, error)
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/mail"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/mailer"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+)
+
+// Mail UseCase
+type mailUC struct {
+	cfg       *config.Config
+	templates *mailer.Templates
+	logger    logger.Logger
+}
+
+// NewMailUseCase Mail UseCase constructor
+func NewMailUseCase(cfg *config.Config, templates *mailer.Templates, log logger.Logger) mail.UseCase {
+	return &mailUC{cfg: cfg, templates: templates, logger: log}
+}
+
+// Preview Render a template with its sample data
+func (u *mailUC) Preview(ctx context.Context, template, lang string) (*mailer.Message, error) {
+	_, span := otel.Tracer.Start(ctx, "mailUC.Preview")
+	defer span.End()
+
+	for _, name := range mailer.TemplateNames() {
+		if name == template {
+			return u.templates.Preview(template, lang)
+		}
+	}
+
+	return nil, httpErr.NewNotFoundError("mail template " + template + " not found")
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PermissionMiddleware only lets principals whose roles grant the permission through, it must run
+// after AuthMiddleware. Personal access tokens also need the permission among their scopes.
+func (mw *MiddlewareManager) PermissionMiddleware(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, err := utils.GetPrincipalFromCtx(c.Request().Context())
+			if err != nil {
+				return mw.unauthorized(c, err)
+			}
+			if !principal.HasScopes(permission) {
+				return mw.forbidden(c, httpErr.ErrInsufficientTokenScope)
+			}
+
+			allowed, err := mw.rbacUC.HasPermission(c.Request().Context(), principal.UserID, permission)
+			if err != nil {
+				return utils.ErrResponseWithLog(c, mw.logger, err)
+			}
+			if !allowed {
+				return mw.forbidden(c, httpErr.ErrPermissionDenied)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// VerifiedEmailMiddleware keeps accounts that haven't verified their email out, it must run after AuthMiddleware
+func (mw *MiddlewareManager) VerifiedEmailMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		principal, err := utils.GetPrincipalFromCtx(c.Request().Context())
+		if err != nil {
+			return mw.unauthorized(c, err)
+		}
+
+		if !principal.EmailVerified {
+			utils.LogResponseError(c, mw.logger, httpErr.ErrEmailNotVerified)
+			return c.JSON(http.StatusForbidden, httpErr.NewForbiddenError(httpErr.ErrEmailNotVerified.Error()))
+		}
+
+		return next(c)
+	}
+}
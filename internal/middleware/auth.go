@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/caller"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+const bearerScheme = "bearer"
+
+// AuthMiddleware authenticates a logged in session from the Authorization bearer header or,
+// for the browser, the jwt cookie, and stores the Principal in the request context.
+// Personal access tokens are rejected, see ScopedAuthMiddleware.
+func (mw *MiddlewareManager) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString, err := mw.extractToken(c)
+		if err != nil {
+			return mw.unauthorized(c, err)
+		}
+		if strings.HasPrefix(tokenString, models.PersonalAccessTokenPrefix) {
+			return mw.unauthorized(c, httpErr.ErrInvalidJWTToken)
+		}
+
+		principal, err := mw.authenticateJWT(c.Request().Context(), tokenString)
+		if err != nil {
+			return mw.unauthorized(c, err)
+		}
+
+		return next(withPrincipal(c, principal))
+	}
+}
+
+// ScopedAuthMiddleware works like AuthMiddleware and additionally accepts personal access
+// tokens that hold every given scope
+func (mw *MiddlewareManager) ScopedAuthMiddleware(scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			tokenString, err := mw.extractToken(c)
+			if err != nil {
+				return mw.unauthorized(c, err)
+			}
+
+			var principal *caller.Principal
+			if strings.HasPrefix(tokenString, models.PersonalAccessTokenPrefix) {
+				principal, err = mw.tokensUC.Authenticate(c.Request().Context(), tokenString)
+			} else {
+				principal, err = mw.authenticateJWT(c.Request().Context(), tokenString)
+			}
+			if err != nil {
+				return mw.unauthorized(c, err)
+			}
+
+			if !principal.HasScopes(scopes...) {
+				utils.LogResponseError(c, mw.logger, httpErr.ErrInsufficientTokenScope)
+				return c.JSON(http.StatusForbidden, httpErr.NewForbiddenError(httpErr.ErrInsufficientTokenScope.Error()))
+			}
+
+			return next(withPrincipal(c, principal))
+		}
+	}
+}
+
+func (mw *MiddlewareManager) authenticateJWT(ctx context.Context, tokenString string) (*caller.Principal, error) {
+	claims, err := mw.tokenManager.Verify(ctx, tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := claims.UserUUID()
+	if err != nil {
+		return nil, httpErr.ErrInvalidJWTClaims
+	}
+
+	// A killed or idle session locks out its access tokens right away
+	if _, err = mw.sessionUC.Touch(ctx, userID, claims.SessionID); err != nil {
+		var restErr httpErr.RestErr
+		if errors.As(err, &restErr) {
+			return nil, httpErr.ErrSessionExpired
+		}
+		return nil, err
+	}
+
+	return &caller.Principal{
+		UserID:        userID,
+		AuthType:      caller.AuthTypeJWT,
+		SessionID:     claims.SessionID,
+		TokenID:       claims.ID,
+		ExpiresAt:     claims.ExpiresAt.Time,
+		AdminLevel:    claims.AdminLevel,
+		Roles:         claims.Roles,
+		EmailVerified: claims.EmailVerified,
+	}, nil
+}
+
+// extractToken prefers the Authorization header and falls back to the jwt cookie
+func (mw *MiddlewareManager) extractToken(c echo.Context) (string, error) {
+	if header := c.Request().Header.Get(echo.HeaderAuthorization); header != "" {
+		parts := strings.Fields(header)
+		if len(parts) != 2 || strings.ToLower(parts[0]) != bearerScheme {
+			return "", httpErr.ErrInvalidJWTToken
+		}
+		return parts[1], nil
+	}
+
+	cookie, err := c.Cookie(mw.cfg.Cookie.Name)
+	if err != nil || cookie.Value == "" {
+		return "", httpErr.ErrNoCookie
+	}
+	return cookie.Value, nil
+}
+
+func withPrincipal(c echo.Context, principal *caller.Principal) echo.Context {
+	ctx := context.WithValue(c.Request().Context(), utils.PrincipalCtxKey{}, principal)
+	c.SetRequest(c.Request().WithContext(ctx))
+	return c
+}
+
+func (mw *MiddlewareManager) unauthorized(c echo.Context, err error) error {
+	utils.LogResponseError(c, mw.logger, err)
+	return c.JSON(http.StatusUnauthorized, httpErr.NewUnauthorizedError(err.Error()))
+}
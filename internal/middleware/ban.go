@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// BanMiddleware rejects requests from banned addresses, networks and ASNs with a 403
+// that explains the ban and how to appeal it
+func (mw *MiddlewareManager) BanMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := mw.bansUC.Check(c.Request().Context(), utils.GetIPAddress(c)); err != nil {
+			return utils.ErrResponseWithLog(c, mw.logger, err)
+		}
+		return next(c)
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/iamaul/go-evonix-backend-api/pkg/csrf"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CSRFMiddleware requires the X-CSRF-Token header on unsafe methods when Server.CSRF is enabled.
+// The header must match the double-submit cookie and carry a valid signature for the session cookie.
+// Requests with an Authorization header are skipped, a cross-site page can't set it.
+func (mw *MiddlewareManager) CSRFMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !mw.cfg.Server.CSRF || c.Request().Header.Get(echo.HeaderAuthorization) != "" {
+			return next(c)
+		}
+		switch c.Request().Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+			return next(c)
+		}
+
+		token := c.Request().Header.Get(csrf.CSRFHeader)
+		if token == "" {
+			return mw.forbidden(c, httpErr.ErrCSRFNotPresented)
+		}
+		cookie, err := c.Cookie(mw.cfg.CSRF.CookieName)
+		if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(token)) != 1 {
+			return mw.forbidden(c, httpErr.ErrWrongCSRFToken)
+		}
+
+		var sessionID string
+		if sessionCookie, err := c.Cookie(mw.cfg.Session.Name); err == nil {
+			sessionID = sessionCookie.Value
+		}
+		secret, err := mw.sessionUC.CSRFSecret(c.Request().Context(), sessionID)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, mw.logger, err)
+		}
+		if err = mw.csrfManager.ValidateToken(token, secret); err != nil {
+			return mw.forbidden(c, err)
+		}
+
+		return next(c)
+	}
+}
+
+func (mw *MiddlewareManager) forbidden(c echo.Context, err error) error {
+	utils.LogResponseError(c, mw.logger, err)
+	return c.JSON(http.StatusForbidden, httpErr.NewForbiddenError(err.Error()))
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httputil"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DebugMiddleware dumps incoming requests, it is only registered when Server.Debug is enabled
+func (mw *MiddlewareManager) DebugMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		dump, err := httputil.DumpRequest(c.Request(), true)
+		if err != nil {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		mw.logger.Info("\nRequest dump begin :--------------\n\n", string(dump), "\n\nRequest dump end :--------------")
+		return next(c)
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/iamaul/go-evonix-backend-api/pkg/mailer"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LanguageMiddleware picks the first supported language of Accept-Language for the emails a
+// request triggers, requests without one get the default mail language
+func (mw *MiddlewareManager) LanguageMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		for _, tag := range strings.Split(c.Request().Header.Get("Accept-Language"), ",") {
+			lang := strings.ToLower(strings.TrimSpace(strings.SplitN(tag, ";", 2)[0]))
+			lang = strings.SplitN(lang, "-", 2)[0]
+			if mailer.IsSupportedLanguage(lang) {
+				req := c.Request()
+				c.SetRequest(req.WithContext(mailer.ContextWithLanguage(req.Context(), lang)))
+				break
+			}
+		}
+		return next(c)
+	}
+}
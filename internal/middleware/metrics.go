@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MetricsMiddleware records hits and response time of every request
+func (mw *MiddlewareManager) MetricsMiddleware(metrics metrics.Metrics) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			status := c.Response().Status
+			if httpErr, ok := err.(*echo.HTTPError); ok {
+				status = httpErr.Code
+			}
+			metrics.ObserveResponseTime(status, c.Request().Method, c.Path(), time.Since(start).Seconds())
+			metrics.IncHits(status, c.Request().Method, c.Path())
+			return err
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/bans"
+	"github.com/iamaul/go-evonix-backend-api/internal/rbac"
+	"github.com/iamaul/go-evonix-backend-api/internal/session"
+	"github.com/iamaul/go-evonix-backend-api/internal/tokens"
+	"github.com/iamaul/go-evonix-backend-api/pkg/csrf"
+	"github.com/iamaul/go-evonix-backend-api/pkg/jwt"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/ratelimit"
+)
+
+// MiddlewareManager holds the dependencies shared by the http middlewares
+type MiddlewareManager struct {
+	cfg          *config.Config
+	tokenManager jwt.TokenManager
+	tokensUC     tokens.UseCase
+	sessionUC    session.UseCase
+	rbacUC       rbac.UseCase
+	bansUC       bans.UseCase
+	limiter      ratelimit.Limiter
+	csrfManager  *csrf.Manager
+	logger       logger.Logger
+}
+
+// NewMiddlewareManager creates the middleware manager
+func NewMiddlewareManager(
+	cfg *config.Config,
+	tokenManager jwt.TokenManager,
+	tokensUC tokens.UseCase,
+	sessionUC session.UseCase,
+	rbacUC rbac.UseCase,
+	bansUC bans.UseCase,
+	limiter ratelimit.Limiter,
+	csrfManager *csrf.Manager,
+	logger logger.Logger,
+) *MiddlewareManager {
+	return &MiddlewareManager{
+		cfg:          cfg,
+		tokenManager: tokenManager,
+		tokensUC:     tokensUC,
+		sessionUC:    sessionUC,
+		rbacUC:       rbacUC,
+		bansUC:       bansUC,
+		limiter:      limiter,
+		csrfManager:  csrfManager,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Rate limit keys
+const (
+	RateLimitKeyIP   = "ip"
+	RateLimitKeyUser = "user"
+)
+
+// RateLimitMiddleware applies the named rule of config.RateLimit. Rules keyed by user need the
+// principal and must come after the auth middleware, anonymous callers are limited by IP.
+// Every answer carries the RateLimit-* headers, a denied request gets a 429 with Retry-After.
+func (mw *MiddlewareManager) RateLimitMiddleware(ruleName string) echo.MiddlewareFunc {
+	rule, ok := mw.rateLimitRule(ruleName)
+	if !ok {
+		mw.logger.Errorf("RateLimitMiddleware, rule %q is not configured, the route is not limited", ruleName)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !ok || !mw.cfg.RateLimit.Enabled {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			key := rule.Name + ":" + RateLimitKeyIP + ":" + utils.GetIPAddress(c)
+			if rule.Key == RateLimitKeyUser {
+				if principal, err := utils.GetPrincipalFromCtx(c.Request().Context()); err == nil {
+					key = rule.Name + ":" + RateLimitKeyUser + ":" + principal.UserID.String()
+				}
+			}
+
+			result, err := mw.limiter.Allow(c.Request().Context(), key, rule.Limit, rule.Period)
+			if err != nil {
+				return utils.ErrResponseWithLog(c, mw.logger, err)
+			}
+
+			header := c.Response().Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+			header.Set("RateLimit-Policy", strconv.Itoa(rule.Limit)+";w="+strconv.Itoa(ceilSeconds(rule.Period)))
+
+			if !result.Allowed {
+				return utils.ErrResponseWithLog(c, mw.logger, httpErr.NewTooManyRequestsError(
+					httpErr.ErrTooManyRequests.Error(),
+					result.RetryAfter,
+					map[string]int{"retry_after": ceilSeconds(result.RetryAfter)},
+				))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func (mw *MiddlewareManager) rateLimitRule(name string) (config.RateLimitRule, bool) {
+	for _, rule := range mw.cfg.RateLimit.Rules {
+		if rule.Name == name && rule.Limit > 0 && rule.Period > 0 {
+			return rule, true
+		}
+	}
+	return config.RateLimitRule{}, false
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestLoggerMiddleware logs method, uri, status and latency of every request
+func (mw *MiddlewareManager) RequestLoggerMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		start := time.Now()
+		err := next(ctx)
+
+		req := ctx.Request()
+		res := ctx.Response()
+		status := res.Status
+		size := res.Size
+		s := time.Since(start).String()
+		requestID := utils.GetRequestID(ctx)
+
+		mw.logger.Infof("RequestID: %s, Method: %s, URI: %s, Status: %v, Size: %v, Time: %s, IPAddress: %s",
+			requestID, req.Method, req.URL, status, size, s, utils.GetIPAddress(ctx),
+		)
+		return err
+	}
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestMetaMiddleware puts the ip, user agent, request id and fingerprint into the request context so
+// usecases can record them, it must run after the request id middleware
+func (mw *MiddlewareManager) RequestMetaMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		c.SetRequest(req.WithContext(context.WithValue(req.Context(), utils.RequestMetaCtxKey{}, utils.GetRequestMeta(c))))
+		return next(c)
+	}
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// Identifier kinds that link accounts
+const (
+	IdentifierIP          = "ip"
+	IdentifierSerial      = "gpci"
+	IdentifierFingerprint = "fingerprint"
+)
+
+// AccountLink is an identifier two accounts share, SharedCount is how many accounts use it
+type AccountLink struct {
+	UserID       uuid.UUID `db:"user_id"`
+	LinkedUserID uuid.UUID `db:"linked_user_id"`
+	Kind         string    `db:"kind"`
+	Value        string    `db:"value"`
+	SharedCount  int       `db:"shared_count"`
+}
+
+// RecordSerialRequest is a gpci serial the gamemode saw on a connection of the account
+type RecordSerialRequest struct {
+	Serial string `json:"serial" validate:"required,max=128,alphanum"`
+}
+
+// AltEvidence is an identifier behind a link and how much it counted
+type AltEvidence struct {
+	Kind        string  `json:"kind"`
+	Value       string  `json:"value"`
+	SharedCount int     `json:"shared_count"`
+	Weight      float64 `json:"weight"`
+}
+
+// AltAccount is an account linked to the one looked up. Hops is 1 for a direct link, 2 when it is
+// linked through Via, the evidence is then that of the link between Via and this account.
+type AltAccount struct {
+	UserID     uuid.UUID     `json:"user_id"`
+	Username   string        `json:"username"`
+	Confidence float64       `json:"confidence"`
+	Hops       int           `json:"hops"`
+	Via        *uuid.UUID    `json:"via,omitempty"`
+	Evidence   []AltEvidence `json:"evidence"`
+}
+
+// AltGraph is a page of the accounts linked to UserID, most likely alts first
+type AltGraph struct {
+	UserID     uuid.UUID     `json:"user_id"`
+	TotalCount int           `json:"total_count"`
+	TotalPages int           `json:"total_pages"`
+	Page       int           `json:"page"`
+	Size       int           `json:"size"`
+	HasMore    bool          `json:"has_more"`
+	Accounts   []*AltAccount `json:"accounts"`
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit log actions
+const (
+	AuditLoginSucceeded           = "login.succeeded"
+	AuditLoginFailed              = "login.failed"
+	AuditLoginTwoFactorFailed     = "login.two_factor_failed"
+	AuditPasswordReset            = "password.reset"
+	AuditEmailChangeRequested     = "email.change_requested"
+	AuditEmailChanged             = "email.changed"
+	AuditEmailVerified            = "email.verified"
+	AuditTwoFactorEnabled         = "two_factor.enabled"
+	AuditTwoFactorDisabled        = "two_factor.disabled"
+	AuditTwoFactorRecoveryRenewed = "two_factor.recovery_codes_renewed"
+	AuditTwoFactorReset           = "two_factor.reset"
+	AuditSessionRevoked           = "session.revoked"
+	AuditSessionsRevoked          = "sessions.revoked"
+	AuditRoleAssigned             = "role.assigned"
+	AuditRoleRemoved              = "role.removed"
+	AuditLockoutCleared           = "lockout.cleared"
+	AuditIPLockoutCleared         = "lockout.ip_cleared"
+	AuditBanCreated               = "ban.created"
+	AuditBanUpdated               = "ban.updated"
+	AuditBanDeleted               = "ban.deleted"
+	AuditAccountRegistered        = "account.registered"
+)
+
+// AuditChange is the old and new value of a changed field
+type AuditChange struct {
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+}
+
+// AuditChanges is the JSON diff of an audit log entry, keyed by field
+type AuditChanges map[string]AuditChange
+
+// Value implements driver.Valuer
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
+
+// Scan implements sql.Scanner
+func (c *AuditChanges) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	case nil:
+		*c = nil
+		return nil
+	default:
+		return errors.New("invalid audit changes column type")
+	}
+}
+
+// AuditLog is an append-only record of a security relevant action. ActorID is who did it,
+// TargetID the account it was done to, they are the same when players act on their own account.
+type AuditLog struct {
+	ID        int64        `json:"id" db:"id"`
+	Action    string       `json:"action" db:"action"`
+	ActorID   *uuid.UUID   `json:"actor_id" db:"actor_id"`
+	TargetID  *uuid.UUID   `json:"target_id" db:"target_id"`
+	IP        string       `json:"ip" db:"ip"`
+	UserAgent string       `json:"user_agent" db:"user_agent"`
+	RequestID string       `json:"request_id" db:"request_id"`
+	Changes   AuditChanges `json:"changes,omitempty" db:"changes"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+}
+
+// AuditLogFilter narrows the staff view of the audit log, zero values don't filter
+type AuditLogFilter struct {
+	Action   string
+	ActorID  *uuid.UUID
+	TargetID *uuid.UUID
+	IP       string
+	From     *time.Time
+	To       *time.Time
+}
+
+// AuditLogList is a page of audit log entries
+type AuditLogList struct {
+	TotalCount int         `json:"total_count"`
+	TotalPages int         `json:"total_pages"`
+	Page       int         `json:"page"`
+	Size       int         `json:"size"`
+	HasMore    bool        `json:"has_more"`
+	Logs       []*AuditLog `json:"logs"`
+}
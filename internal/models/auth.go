@@ -0,0 +1,68 @@
+package models
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/pkg/jwt"
+)
+
+// RegisterRequest creates an account, the username becomes the in-game name so it follows
+// the SA-MP nickname rules
+type RegisterRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=20,samp_username"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,max=128"`
+}
+
+// LoginRequest is the first login step, Login is either the username or the email.
+// IP and UserAgent are filled in by the handler.
+type LoginRequest struct {
+	Login     string `json:"login" validate:"required,max=255"`
+	Password  string `json:"password" validate:"required,max=128"`
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// LoginTwoFactorRequest is the second login step, it takes either a TOTP code or a recovery code
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode   string `json:"recovery_code" validate:"required_without=Code,omitempty,max=32"`
+	IP             string `json:"-"`
+	UserAgent      string `json:"-"`
+}
+
+// RefreshRequest carries the refresh token to rotate
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// LoginResult either carries the tokens or, when two-factor authentication is enabled,
+// the challenge token for the second step
+type LoginResult struct {
+	User              *User       `json:"user,omitempty"`
+	Tokens            *jwt.Tokens `json:"tokens,omitempty"`
+	SessionID         string      `json:"session_id,omitempty"`
+	TwoFactorRequired bool        `json:"two_factor_required"`
+	ChallengeToken    string      `json:"challenge_token,omitempty"`
+}
+
+// ForgotPasswordRequest starts a password reset
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+// ResetPasswordRequest completes a password reset with the token from the email
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required,max=128"`
+	Password string `json:"password" validate:"required,max=128"`
+}
+
+// VerifyEmailRequest carries the token from a verification email
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required,max=128"`
+}
+
+// ChangeEmailRequest starts an email change, the password confirms it is the owner asking
+type ChangeEmailRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,max=128"`
+}
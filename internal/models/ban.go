@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ban types
+const (
+	BanTypeIP   = "ip"
+	BanTypeCIDR = "cidr"
+	BanTypeASN  = "asn"
+)
+
+// Ban blocks an address, a network or every address of an autonomous system from the UCP.
+// Value is the normalized address, the network in CIDR notation or the AS number.
+type Ban struct {
+	ID        int64      `json:"id" db:"id"`
+	Type      string     `json:"type" db:"type"`
+	Value     string     `json:"value" db:"value"`
+	Reason    string     `json:"reason" db:"reason"`
+	BannedBy  *uuid.UUID `json:"banned_by" db:"banned_by"`
+	ExpiresAt *time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Active reports whether the ban is in effect at the given time
+func (b *Ban) Active(now time.Time) bool {
+	return b.ExpiresAt == nil || b.ExpiresAt.After(now)
+}
+
+// BanRequest creates or replaces a ban, a nil ExpiresAt bans permanently
+type BanRequest struct {
+	Type      string     `json:"type" validate:"required,oneof=ip cidr asn"`
+	Value     string     `json:"value" validate:"required,max=64"`
+	Reason    string     `json:"reason" validate:"required,max=255"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// BanList is a page of bans
+type BanList struct {
+	TotalCount int    `json:"total_count"`
+	TotalPages int    `json:"total_pages"`
+	Page       int    `json:"page"`
+	Size       int    `json:"size"`
+	HasMore    bool   `json:"has_more"`
+	Bans       []*Ban `json:"bans"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Login record results
+const (
+	LoginRecordSucceeded       = "succeeded"
+	LoginRecordFailed          = "failed"
+	LoginRecordTwoFactorFailed = "two_factor_failed"
+	LoginRecordLockedOut       = "locked_out"
+)
+
+// LoginRecord is a login attempt on an account with where it came from. The ASN is only shown
+// to staff, it tells VPN and hosting provider logins apart from home connections.
+type LoginRecord struct {
+	ID             int64     `json:"id" db:"id"`
+	UserID         uuid.UUID `json:"user_id" db:"user_id"`
+	Result         string    `json:"result" db:"result"`
+	IP             string    `json:"ip" db:"ip"`
+	UserAgent      string    `json:"user_agent" db:"user_agent"`
+	CountryCode    string    `json:"country_code" db:"country_code"`
+	Country        string    `json:"country" db:"country"`
+	City           string    `json:"city" db:"city"`
+	ASN            *uint     `json:"asn,omitempty" db:"asn"`
+	ASOrganization string    `json:"as_organization,omitempty" db:"as_organization"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+
+	// NewCountry is set on a successful login from a country the account never logged in from
+	NewCountry bool `json:"-" db:"-"`
+}
+
+// LoginRecordFilter narrows the staff view of the login history, zero values don't filter
+type LoginRecordFilter struct {
+	UserID *uuid.UUID
+	Result string
+	IP     string
+	ASN    *uint
+}
+
+// LoginRecordList is a page of login records
+type LoginRecordList struct {
+	TotalCount int            `json:"total_count"`
+	TotalPages int            `json:"total_pages"`
+	Page       int            `json:"page"`
+	Size       int            `json:"size"`
+	HasMore    bool           `json:"has_more"`
+	Logins     []*LoginRecord `json:"logins"`
+}
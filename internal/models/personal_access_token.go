@@ -0,0 +1,96 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PersonalAccessTokenPrefix marks a bearer token as a personal access token rather than a JWT
+const PersonalAccessTokenPrefix = "evx_pat_"
+
+// Personal access token scopes, every scope is read-only. A token can also be scoped to a staff
+// permission like alts:write, the account must still be granted it to use the token on the route.
+const (
+	ScopeProfileRead    = "profile:read"
+	ScopeCharactersRead = "characters:read"
+	ScopeStatsRead      = "stats:read"
+)
+
+// Scopes is a set of scopes stored as a comma separated column
+type Scopes []string
+
+// Value implements driver.Valuer
+func (s Scopes) Value() (driver.Value, error) {
+	return strings.Join(s, ","), nil
+}
+
+// Scan implements sql.Scanner
+func (s *Scopes) Scan(src interface{}) error {
+	var raw string
+	switch v := src.(type) {
+	case []byte:
+		raw = string(v)
+	case string:
+		raw = v
+	case nil:
+		*s = nil
+		return nil
+	default:
+		return errors.New("invalid scopes column type")
+	}
+
+	if raw == "" {
+		*s = Scopes{}
+		return nil
+	}
+	*s = strings.Split(raw, ",")
+	return nil
+}
+
+// Has reports whether the scope is in the set
+func (s Scopes) Has(scope string) bool {
+	for _, v := range s {
+		if v == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// PersonalAccessToken is a scoped, revocable credential for bots and community tools
+type PersonalAccessToken struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	Scopes     Scopes     `json:"scopes" db:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at" db:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsActive reports whether the token is neither revoked nor expired
+func (t *PersonalAccessToken) IsActive(now time.Time) bool {
+	if t.RevokedAt != nil {
+		return false
+	}
+	return t.ExpiresAt == nil || now.Before(*t.ExpiresAt)
+}
+
+// CreatePersonalAccessToken is the request to create a personal access token
+type CreatePersonalAccessToken struct {
+	Name          string   `json:"name" validate:"required,min=1,max=64"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=profile:read characters:read stats:read alts:write"`
+	ExpiresInDays int      `json:"expires_in_days" validate:"omitempty,min=1,max=365"`
+}
+
+// CreatedPersonalAccessToken carries the plain token, it is only ever shown once
+type CreatedPersonalAccessToken struct {
+	*PersonalAccessToken
+	Token string `json:"token"`
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Roles, admin level roles are named by AdminRole
+const (
+	RolePlayer     = "player"
+	RoleHelper     = "helper"
+	RoleModerator  = "moderator"
+	RoleDeveloper  = "developer"
+	RoleOwner      = "owner"
+	RoleGameServer = "game_server"
+
+	MaxAdminLevel   = 6
+	adminRolePrefix = "admin_"
+)
+
+// Permissions checked by the staff routes, PermissionAll is granted to the owner only
+const (
+	PermissionAll            = "*"
+	PermissionUsersRead      = "users:read"
+	PermissionBansRead       = "bans:read"
+	PermissionBansWrite      = "bans:write"
+	PermissionSessionsRead   = "sessions:read"
+	PermissionSessionsWrite  = "sessions:write"
+	PermissionLockoutsWrite  = "lockouts:write"
+	PermissionTwoFactorReset = "twofactor:reset"
+	PermissionAuditRead      = "audit:read"
+	PermissionRolesRead      = "roles:read"
+	PermissionRolesWrite     = "roles:write"
+	PermissionMailPreview    = "mail:preview"
+	PermissionAltsRead       = "alts:read"
+	PermissionAltsWrite      = "alts:write"
+)
+
+// Role groups permissions, a higher priority outranks a lower one
+type Role struct {
+	ID          string   `json:"id" db:"id"`
+	Name        string   `json:"name" db:"name"`
+	Priority    int      `json:"priority" db:"priority"`
+	Permissions []string `json:"permissions" db:"-"`
+}
+
+// RolePermission is a row of the role_permissions table
+type RolePermission struct {
+	RoleID       string `db:"role_id"`
+	PermissionID string `db:"permission_id"`
+}
+
+// UserRole is a role assigned to a user through the UCP
+type UserRole struct {
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	RoleID    string     `json:"role_id" db:"role_id"`
+	GrantedBy *uuid.UUID `json:"granted_by" db:"granted_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// UserRoles are the effective roles and permissions of a user
+type UserRoles struct {
+	UserID      uuid.UUID `json:"user_id"`
+	AdminLevel  int       `json:"admin_level"`
+	Roles       []string  `json:"roles"`
+	Permissions []string  `json:"permissions"`
+}
+
+// HasPermission reports whether the user was granted the permission
+func (r *UserRoles) HasPermission(permission string) bool {
+	for _, p := range r.Permissions {
+		if p == permission || p == PermissionAll {
+			return true
+		}
+	}
+	return false
+}
+
+// AdminRole returns the role of an in-game admin level
+func AdminRole(level int) string {
+	return adminRolePrefix + strconv.Itoa(level)
+}
+
+// AdminLevelOfRole returns the in-game admin level of an admin level role
+func AdminLevelOfRole(role string) (int, bool) {
+	if !strings.HasPrefix(role, adminRolePrefix) {
+		return 0, false
+	}
+	level, err := strconv.Atoi(strings.TrimPrefix(role, adminRolePrefix))
+	if err != nil || level < 1 || level > MaxAdminLevel {
+		return 0, false
+	}
+	return level, true
+}
+
+// EffectiveRoles combines the implicit player role, the role of the gamemode admin level
+// and the roles assigned through the UCP
+func EffectiveRoles(adminLevel int, assigned []string) []string {
+	roles := make([]string, 0, len(assigned)+2)
+	roles = append(roles, RolePlayer)
+	if adminLevel > 0 {
+		if adminLevel > MaxAdminLevel {
+			adminLevel = MaxAdminLevel
+		}
+		roles = append(roles, AdminRole(adminLevel))
+	}
+	return append(roles, assigned...)
+}
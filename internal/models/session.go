@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a logged in device, its ID is the session ID (sid) carried by the tokens of the login
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CSRFSecret string    `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	// Current marks the session making the request in session lists
+	Current bool `json:"current"`
+}
+
+// CSRFToken is sent back in the X-CSRF-Token header of unsafe requests
+type CSRFToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TwoFactor is the TOTP enrollment of a user, it only protects logins once EnabledAt is set
+type TwoFactor struct {
+	UserID       uuid.UUID  `db:"user_id"`
+	Secret       string     `db:"secret"`
+	LastUsedStep int64      `db:"last_used_step"`
+	EnabledAt    *time.Time `db:"enabled_at"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+// IsEnabled reports whether the enrollment has been confirmed
+func (t *TwoFactor) IsEnabled() bool {
+	return t.EnabledAt != nil
+}
+
+// TwoFactorEnrollment is returned when enrollment starts, the frontend renders the URI as QR code
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// TOTPCodeRequest carries a TOTP code, recovery codes are not accepted
+type TOTPCodeRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// TwoFactorCodeRequest carries a TOTP code, or a recovery code where the endpoint allows it
+type TwoFactorCodeRequest struct {
+	Code         string `json:"code" validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recovery_code" validate:"required_without=Code,omitempty,max=32"`
+}
+
+// RecoveryCodes are shown once, only their hashes are stored
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+// TwoFactorStatus tells the account settings page the current state
+type TwoFactorStatus struct {
+	Enabled           bool       `json:"enabled"`
+	EnabledAt         *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesLeft int        `json:"recovery_codes_left"`
+}
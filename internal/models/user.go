@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// In-game admin levels, mirrored from the gamemode
+const (
+	AdminLevelNone      = 0
+	AdminLevelLeadAdmin = 4
+)
+
+// User is a UCP account. EmailVerifiedAt stays nil until the player proves they own Email,
+// PendingEmail is the new address of an email change and Email stays active until it is confirmed.
+type User struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	Username        string     `json:"username" db:"username"`
+	Email           string     `json:"email" db:"email"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at" db:"email_verified_at"`
+	PendingEmail    *string    `json:"pending_email,omitempty" db:"pending_email"`
+	Password        string     `json:"-" db:"password"`
+	AdminLevel      int        `json:"admin_level" db:"admin_level"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+
+	// PasswordAlgorithm and PasswordSalt are set for hashes imported from the gamemode or the v1 UCP,
+	// they are cleared once the password is rehashed
+	PasswordAlgorithm *string `json:"-" db:"password_algorithm"`
+	PasswordSalt      *string `json:"-" db:"password_salt"`
+}
+
+// IsEmailVerified reports whether the current email has been verified
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
@@ -0,0 +1,12 @@
+package rbac
+
+import "github.com/labstack/echo/v4"
+
+// Handlers RBAC HTTP Handlers interface
+type Handlers interface {
+	ListRoles() echo.HandlerFunc
+	GetMine() echo.HandlerFunc
+	GetByUser() echo.HandlerFunc
+	Assign() echo.HandlerFunc
+	Unassign() echo.HandlerFunc
+}
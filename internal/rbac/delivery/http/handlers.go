@@ -0,0 +1,127 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/rbac"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// RBAC handlers
+type rbacHandlers struct {
+	cfg    *config.Config
+	rbacUC rbac.UseCase
+	logger logger.Logger
+}
+
+// NewRBACHandlers RBAC handlers constructor
+func NewRBACHandlers(cfg *config.Config, rbacUC rbac.UseCase, log logger.Logger) rbac.Handlers {
+	return &rbacHandlers{cfg: cfg, rbacUC: rbacUC, logger: log}
+}
+
+// ListRoles Get every role with its permissions
+func (h *rbacHandlers) ListRoles() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "rbacHandlers.ListRoles")
+		defer span.End()
+
+		roles, err := h.rbacUC.ListRoles(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: roles, Success: true})
+	}
+}
+
+// GetMine Get the roles and permissions of the current user
+func (h *rbacHandlers) GetMine() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "rbacHandlers.GetMine")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		userRoles, err := h.rbacUC.GetUserRoles(ctx, principal.UserID)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: userRoles, Success: true})
+	}
+}
+
+// GetByUser Get the roles and permissions of any user, for staff
+func (h *rbacHandlers) GetByUser() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "rbacHandlers.GetByUser")
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		userRoles, err := h.rbacUC.GetUserRoles(ctx, userID)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: userRoles, Success: true})
+	}
+}
+
+// Assign Give a role to a user
+func (h *rbacHandlers) Assign() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "rbacHandlers.Assign")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.rbacUC.Assign(ctx, principal.UserID, userID, c.Param("role_id")); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// Unassign Take a role away from a user
+func (h *rbacHandlers) Unassign() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "rbacHandlers.Unassign")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.rbacUC.Unassign(ctx, principal.UserID, userID, c.Param("role_id")); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,22 @@
+package http
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/internal/middleware"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/rbac"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MapRoleRoutes Map the roles route of the account settings
+func MapRoleRoutes(accountGroup *echo.Group, h rbac.Handlers, mw *middleware.MiddlewareManager) {
+	accountGroup.GET("/roles", h.GetMine(), mw.AuthMiddleware)
+}
+
+// MapRoleAdminRoutes Map role management routes of the staff tools
+func MapRoleAdminRoutes(adminGroup *echo.Group, h rbac.Handlers, mw *middleware.MiddlewareManager) {
+	adminGroup.GET("/roles", h.ListRoles(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionRolesRead))
+	adminGroup.GET("/users/:user_id/roles", h.GetByUser(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionRolesRead))
+	adminGroup.PUT("/users/:user_id/roles/:role_id", h.Assign(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionRolesWrite))
+	adminGroup.DELETE("/users/:user_id/roles/:role_id", h.Unassign(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionRolesWrite))
+}
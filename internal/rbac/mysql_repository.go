@@ -0,0 +1,21 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Repository RBAC MySQL repository interface
+type Repository interface {
+	// ListRoles returns every role with its permissions, highest priority first
+	ListRoles(ctx context.Context) ([]*models.Role, error)
+	// ListUserRoles returns the roles assigned through the UCP, admin level roles are not among them
+	ListUserRoles(ctx context.Context, userID uuid.UUID) ([]*models.UserRole, error)
+	// GetPermissions returns the distinct permissions of the roles
+	GetPermissions(ctx context.Context, roleIDs []string) ([]string, error)
+	AddUserRole(ctx context.Context, userRole *models.UserRole) error
+	DeleteUserRole(ctx context.Context, userID uuid.UUID, roleID string) error
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/rbac"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// RBAC Repository
+type rbacRepo struct {
+	db *sqlx.DB
+}
+
+// NewRBACRepository RBAC Repository constructor
+func NewRBACRepository(db *sqlx.DB) rbac.Repository {
+	return &rbacRepo{db: db}
+}
+
+// ListRoles Get every role with its permissions
+func (r *rbacRepo) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	ctx, span := otel.Tracer.Start(ctx, "rbacRepo.ListRoles")
+	defer span.End()
+
+	roles := make([]*models.Role, 0)
+	if err := r.db.SelectContext(ctx, &roles, listRoles); err != nil {
+		return nil, errors.Wrap(err, "rbacRepo.ListRoles.SelectContext")
+	}
+
+	var rolePermissions []models.RolePermission
+	if err := r.db.SelectContext(ctx, &rolePermissions, listRolePermissions); err != nil {
+		return nil, errors.Wrap(err, "rbacRepo.ListRoles.SelectContext.Permissions")
+	}
+
+	byID := make(map[string]*models.Role, len(roles))
+	for _, role := range roles {
+		role.Permissions = []string{}
+		byID[role.ID] = role
+	}
+	for _, rp := range rolePermissions {
+		if role, ok := byID[rp.RoleID]; ok {
+			role.Permissions = append(role.Permissions, rp.PermissionID)
+		}
+	}
+
+	return roles, nil
+}
+
+// ListUserRoles Get the roles assigned to a user
+func (r *rbacRepo) ListUserRoles(ctx context.Context, userID uuid.UUID) ([]*models.UserRole, error) {
+	ctx, span := otel.Tracer.Start(ctx, "rbacRepo.ListUserRoles")
+	defer span.End()
+
+	userRoles := make([]*models.UserRole, 0)
+	if err := r.db.SelectContext(ctx, &userRoles, listUserRoles, userID); err != nil {
+		return nil, errors.Wrap(err, "rbacRepo.ListUserRoles.SelectContext")
+	}
+
+	return userRoles, nil
+}
+
+// GetPermissions Get the permissions of a set of roles
+func (r *rbacRepo) GetPermissions(ctx context.Context, roleIDs []string) ([]string, error) {
+	ctx, span := otel.Tracer.Start(ctx, "rbacRepo.GetPermissions")
+	defer span.End()
+
+	permissions := make([]string, 0)
+	if len(roleIDs) == 0 {
+		return permissions, nil
+	}
+
+	query, args, err := sqlx.In(getRolesPermissions, roleIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "rbacRepo.GetPermissions.In")
+	}
+	if err = r.db.SelectContext(ctx, &permissions, r.db.Rebind(query), args...); err != nil {
+		return nil, errors.Wrap(err, "rbacRepo.GetPermissions.SelectContext")
+	}
+
+	return permissions, nil
+}
+
+// AddUserRole Assign a role to a user, assigning it again changes nothing
+func (r *rbacRepo) AddUserRole(ctx context.Context, userRole *models.UserRole) error {
+	ctx, span := otel.Tracer.Start(ctx, "rbacRepo.AddUserRole")
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, addUserRole, userRole.UserID, userRole.RoleID, userRole.GrantedBy); err != nil {
+		return errors.Wrap(err, "rbacRepo.AddUserRole.ExecContext")
+	}
+
+	return nil
+}
+
+// DeleteUserRole Remove a role from a user
+func (r *rbacRepo) DeleteUserRole(ctx context.Context, userID uuid.UUID, roleID string) error {
+	ctx, span := otel.Tracer.Start(ctx, "rbacRepo.DeleteUserRole")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteUserRole, userID, roleID)
+	if err != nil {
+		return errors.Wrap(err, "rbacRepo.DeleteUserRole.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "rbacRepo.DeleteUserRole.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "rbacRepo.DeleteUserRole.rowsAffected")
+	}
+
+	return nil
+}
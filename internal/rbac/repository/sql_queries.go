@@ -0,0 +1,15 @@
+package repository
+
+const (
+	listRoles = `SELECT id, name, priority FROM roles ORDER BY priority DESC`
+
+	listRolePermissions = `SELECT role_id, permission_id FROM role_permissions ORDER BY permission_id`
+
+	listUserRoles = `SELECT user_id, role_id, granted_by, created_at FROM user_roles WHERE user_id = ? ORDER BY created_at`
+
+	getRolesPermissions = `SELECT DISTINCT permission_id FROM role_permissions WHERE role_id IN (?) ORDER BY permission_id`
+
+	addUserRole = `INSERT IGNORE INTO user_roles (user_id, role_id, granted_by) VALUES (?, ?, ?)`
+
+	deleteUserRole = `DELETE FROM user_roles WHERE user_id = ? AND role_id = ?`
+)
@@ -0,0 +1,22 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// UseCase RBAC UseCase interface
+type UseCase interface {
+	ListRoles(ctx context.Context) ([]*models.Role, error)
+	// GetUserRoles returns the effective roles and permissions of a user
+	GetUserRoles(ctx context.Context, userID uuid.UUID) (*models.UserRoles, error)
+	HasPermission(ctx context.Context, userID uuid.UUID, permission string) (bool, error)
+	// Assign gives a role to a user, admin level roles set the in-game admin level instead.
+	// The actor must outrank both the role and the user.
+	Assign(ctx context.Context, actorID, userID uuid.UUID, roleID string) error
+	// Unassign takes a role away from a user, with the same rules as Assign
+	Unassign(ctx context.Context, actorID, userID uuid.UUID, roleID string) error
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/audit"
+	"github.com/iamaul/go-evonix-backend-api/internal/auth"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/rbac"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// RBAC UseCase
+type rbacUC struct {
+	cfg      *config.Config
+	rbacRepo rbac.Repository
+	authRepo auth.Repository
+	auditUC  audit.UseCase
+	logger   logger.Logger
+}
+
+// NewRBACUseCase RBAC UseCase constructor
+func NewRBACUseCase(cfg *config.Config, rbacRepo rbac.Repository, authRepo auth.Repository, auditUC audit.UseCase, log logger.Logger) rbac.UseCase {
+	return &rbacUC{cfg: cfg, rbacRepo: rbacRepo, authRepo: authRepo, auditUC: auditUC, logger: log}
+}
+
+// ListRoles Get every role with its permissions
+func (u *rbacUC) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	ctx, span := otel.Tracer.Start(ctx, "rbacUC.ListRoles")
+	defer span.End()
+
+	return u.rbacRepo.ListRoles(ctx)
+}
+
+// GetUserRoles Get the effective roles and permissions of a user
+func (u *rbacUC) GetUserRoles(ctx context.Context, userID uuid.UUID) (*models.UserRoles, error) {
+	ctx, span := otel.Tracer.Start(ctx, "rbacUC.GetUserRoles")
+	defer span.End()
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	userRoles, err := u.rbacRepo.ListUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := make([]string, 0, len(userRoles))
+	for _, userRole := range userRoles {
+		assigned = append(assigned, userRole.RoleID)
+	}
+	roles := models.EffectiveRoles(user.AdminLevel, assigned)
+
+	permissions, err := u.rbacRepo.GetPermissions(ctx, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserRoles{UserID: userID, AdminLevel: user.AdminLevel, Roles: roles, Permissions: permissions}, nil
+}
+
+// HasPermission Check whether a user was granted a permission
+func (u *rbacUC) HasPermission(ctx context.Context, userID uuid.UUID, permission string) (bool, error) {
+	ctx, span := otel.Tracer.Start(ctx, "rbacUC.HasPermission")
+	defer span.End()
+
+	userRoles, err := u.GetUserRoles(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return userRoles.HasPermission(permission), nil
+}
+
+// Assign Give a role to a user
+func (u *rbacUC) Assign(ctx context.Context, actorID, userID uuid.UUID, roleID string) error {
+	ctx, span := otel.Tracer.Start(ctx, "rbacUC.Assign")
+	defer span.End()
+
+	if err := u.checkOutranks(ctx, actorID, userID, roleID); err != nil {
+		return err
+	}
+
+	// The gamemode owns the admin level, so admin roles are written to its column
+	changes := models.AuditChanges{"role": {New: roleID}}
+	if level, ok := models.AdminLevelOfRole(roleID); ok {
+		user, err := u.authRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if err = u.authRepo.UpdateAdminLevel(ctx, userID, level); err != nil {
+			return err
+		}
+		changes["admin_level"] = models.AuditChange{Old: user.AdminLevel, New: level}
+	} else if err := u.rbacRepo.AddUserRole(ctx, &models.UserRole{UserID: userID, RoleID: roleID, GrantedBy: &actorID}); err != nil {
+		return err
+	}
+
+	u.auditUC.Record(ctx, &models.AuditLog{Action: models.AuditRoleAssigned, ActorID: &actorID, TargetID: &userID, Changes: changes})
+	return nil
+}
+
+// Unassign Take a role away from a user
+func (u *rbacUC) Unassign(ctx context.Context, actorID, userID uuid.UUID, roleID string) error {
+	ctx, span := otel.Tracer.Start(ctx, "rbacUC.Unassign")
+	defer span.End()
+
+	if err := u.checkOutranks(ctx, actorID, userID, roleID); err != nil {
+		return err
+	}
+
+	if level, ok := models.AdminLevelOfRole(roleID); ok {
+		user, err := u.authRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.AdminLevel != level {
+			return httpErr.NewNotFoundError("user does not have this role")
+		}
+		if err = u.authRepo.UpdateAdminLevel(ctx, userID, models.AdminLevelNone); err != nil {
+			return err
+		}
+		u.recordRemoved(ctx, actorID, userID, models.AuditChanges{
+			"role":        {Old: roleID},
+			"admin_level": {Old: level, New: models.AdminLevelNone},
+		})
+		return nil
+	}
+
+	if err := u.rbacRepo.DeleteUserRole(ctx, userID, roleID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return httpErr.NewNotFoundError("user does not have this role")
+		}
+		return err
+	}
+	u.recordRemoved(ctx, actorID, userID, models.AuditChanges{"role": {Old: roleID}})
+	return nil
+}
+
+func (u *rbacUC) recordRemoved(ctx context.Context, actorID, userID uuid.UUID, changes models.AuditChanges) {
+	u.auditUC.Record(ctx, &models.AuditLog{Action: models.AuditRoleRemoved, ActorID: &actorID, TargetID: &userID, Changes: changes})
+}
+
+// checkOutranks only lets actors change roles below their own highest role, on users they outrank.
+// Staff can't change their own roles either.
+func (u *rbacUC) checkOutranks(ctx context.Context, actorID, userID uuid.UUID, roleID string) error {
+	if roleID == models.RolePlayer {
+		return httpErr.NewBadRequestError("every user has the player role")
+	}
+
+	roles, err := u.rbacRepo.ListRoles(ctx)
+	if err != nil {
+		return err
+	}
+	priorities := make(map[string]int, len(roles))
+	for _, role := range roles {
+		priorities[role.ID] = role.Priority
+	}
+	rolePriority, ok := priorities[roleID]
+	if !ok {
+		return httpErr.NewNotFoundError("role not found")
+	}
+
+	actor, err := u.GetUserRoles(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	user, err := u.GetUserRoles(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	actorPriority := highestPriority(actor.Roles, priorities)
+	if actorPriority <= rolePriority || actorPriority <= highestPriority(user.Roles, priorities) {
+		return httpErr.NewRestError(http.StatusForbidden, httpErr.ErrPermissionDenied.Error(), "you can only manage roles below your own")
+	}
+	return nil
+}
+
+func highestPriority(roles []string, priorities map[string]int) int {
+	highest := -1
+	for _, role := range roles {
+		if priority, ok := priorities[role]; ok && priority > highest {
+			highest = priority
+		}
+	}
+	return highest
+}
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	altsHttp "github.com/iamaul/go-evonix-backend-api/internal/alts/delivery/http"
+	altsRepository "github.com/iamaul/go-evonix-backend-api/internal/alts/repository"
+	altsUseCase "github.com/iamaul/go-evonix-backend-api/internal/alts/usecase"
+	auditHttp "github.com/iamaul/go-evonix-backend-api/internal/audit/delivery/http"
+	auditRepository "github.com/iamaul/go-evonix-backend-api/internal/audit/repository"
+	auditUseCase "github.com/iamaul/go-evonix-backend-api/internal/audit/usecase"
+	authHttp "github.com/iamaul/go-evonix-backend-api/internal/auth/delivery/http"
+	authRepository "github.com/iamaul/go-evonix-backend-api/internal/auth/repository"
+	authUseCase "github.com/iamaul/go-evonix-backend-api/internal/auth/usecase"
+	bansHttp "github.com/iamaul/go-evonix-backend-api/internal/bans/delivery/http"
+	bansRepository "github.com/iamaul/go-evonix-backend-api/internal/bans/repository"
+	bansUseCase "github.com/iamaul/go-evonix-backend-api/internal/bans/usecase"
+	loginGuardHttp "github.com/iamaul/go-evonix-backend-api/internal/loginguard/delivery/http"
+	loginGuardRepository "github.com/iamaul/go-evonix-backend-api/internal/loginguard/repository"
+	loginGuardUseCase "github.com/iamaul/go-evonix-backend-api/internal/loginguard/usecase"
+	loginHistoryHttp "github.com/iamaul/go-evonix-backend-api/internal/loginhistory/delivery/http"
+	loginHistoryRepository "github.com/iamaul/go-evonix-backend-api/internal/loginhistory/repository"
+	loginHistoryUseCase "github.com/iamaul/go-evonix-backend-api/internal/loginhistory/usecase"
+	mailHttp "github.com/iamaul/go-evonix-backend-api/internal/mail/delivery/http"
+	mailUseCase "github.com/iamaul/go-evonix-backend-api/internal/mail/usecase"
+	apiMiddlewares "github.com/iamaul/go-evonix-backend-api/internal/middleware"
+	rbacHttp "github.com/iamaul/go-evonix-backend-api/internal/rbac/delivery/http"
+	rbacRepository "github.com/iamaul/go-evonix-backend-api/internal/rbac/repository"
+	rbacUseCase "github.com/iamaul/go-evonix-backend-api/internal/rbac/usecase"
+	sessionHttp "github.com/iamaul/go-evonix-backend-api/internal/session/delivery/http"
+	sessionRepository "github.com/iamaul/go-evonix-backend-api/internal/session/repository"
+	sessionUseCase "github.com/iamaul/go-evonix-backend-api/internal/session/usecase"
+	tokensHttp "github.com/iamaul/go-evonix-backend-api/internal/tokens/delivery/http"
+	tokensRepository "github.com/iamaul/go-evonix-backend-api/internal/tokens/repository"
+	tokensUseCase "github.com/iamaul/go-evonix-backend-api/internal/tokens/usecase"
+	twoFactorHttp "github.com/iamaul/go-evonix-backend-api/internal/twofactor/delivery/http"
+	twoFactorRepository "github.com/iamaul/go-evonix-backend-api/internal/twofactor/repository"
+	twoFactorUseCase "github.com/iamaul/go-evonix-backend-api/internal/twofactor/usecase"
+	"github.com/iamaul/go-evonix-backend-api/pkg/clientip"
+	"github.com/iamaul/go-evonix-backend-api/pkg/csrf"
+	"github.com/iamaul/go-evonix-backend-api/pkg/hash"
+	"github.com/iamaul/go-evonix-backend-api/pkg/jwt"
+	"github.com/iamaul/go-evonix-backend-api/pkg/mailer"
+	"github.com/iamaul/go-evonix-backend-api/pkg/password"
+	"github.com/iamaul/go-evonix-backend-api/pkg/ratelimit"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// MapHandlers registers the middlewares and every route of the api
+func (s *Server) MapHandlers(e *echo.Echo) error {
+	// Init repositories
+	aRepo := authRepository.NewAuthRepository(s.db)
+	authRedisRepo := authRepository.NewAuthRedisRepo(s.redisClient, s.cfg)
+	tRepo := tokensRepository.NewTokensRepository(s.db)
+	tfRepo := twoFactorRepository.NewTwoFactorRepository(s.db)
+	sessionRedisRepo := sessionRepository.NewSessionRedisRepo(s.redisClient, s.cfg)
+	loginGuardRedisRepo := loginGuardRepository.NewLoginGuardRedisRepo(s.redisClient, s.cfg)
+	rbacRepo := rbacRepository.NewRBACRepository(s.db)
+	auditRepo := auditRepository.NewAuditRepository(s.db)
+	bansRepo := bansRepository.NewBansRepository(s.db)
+	loginHistoryRepo := loginHistoryRepository.NewLoginHistoryRepository(s.db)
+	altsRepo := altsRepository.NewAltsRepository(s.db)
+	bansRedisRepo := bansRepository.NewBansRedisRepo(s.redisClient, s.cfg)
+	identityLoader := authUseCase.NewIdentityLoader(aRepo, rbacRepo)
+
+	jwtKeys, err := jwt.LoadKeySet(s.cfg)
+	if err != nil {
+		return err
+	}
+	tokenManager, err := jwt.NewManager(
+		jwtKeys,
+		s.cfg.Jwt,
+		jwt.NewRedisRefreshStore(s.redisClient, s.cfg.Jwt.RefreshPrefix),
+		jwt.NewRedisRevocationStore(s.redisClient, s.cfg.Jwt.RevocationPrefix),
+		identityLoader,
+	)
+	if err != nil {
+		return err
+	}
+
+	hasher, err := hash.NewPasswordHasher(s.cfg.PasswordHashing)
+	if err != nil {
+		return err
+	}
+	passwordPolicy, err := password.NewPolicy(s.cfg.PasswordPolicy)
+	if err != nil {
+		return err
+	}
+	csrfManager, err := csrf.NewManager(s.cfg.CSRF.Secret, s.cfg.CSRF.Expire)
+	if err != nil {
+		return err
+	}
+	ipResolver, err := clientip.NewResolver(s.cfg.ClientIP)
+	if err != nil {
+		return err
+	}
+	mailTemplates, err := mailer.NewTemplates(s.cfg.Mailer.DefaultLanguage)
+	if err != nil {
+		return err
+	}
+
+	// Init useCases
+	auditUC := auditUseCase.NewAuditUseCase(s.cfg, auditRepo, s.logger)
+	tokensUC := tokensUseCase.NewTokensUseCase(s.cfg, tRepo, s.logger)
+	twoFactorUC := twoFactorUseCase.NewTwoFactorUseCase(s.cfg, tfRepo, aRepo, auditUC, s.logger)
+	sessionUC := sessionUseCase.NewSessionUseCase(s.cfg, sessionRedisRepo, tokenManager, tokensUC, auditUC, s.logger)
+	loginGuardUC := loginGuardUseCase.NewLoginGuardUseCase(s.cfg, loginGuardRedisRepo, auditUC, s.metrics, s.logger)
+	loginHistoryUC := loginHistoryUseCase.NewLoginHistoryUseCase(s.cfg, loginHistoryRepo, s.geoIP, s.logger)
+	altsUC := altsUseCase.NewAltsUseCase(s.cfg, altsRepo, aRepo, s.logger)
+	authUC := authUseCase.NewAuthUseCase(
+		s.cfg,
+		aRepo,
+		authRedisRepo,
+		twoFactorUC,
+		sessionUC,
+		loginGuardUC,
+		auditUC,
+		loginHistoryUC,
+		altsUC,
+		tokenManager,
+		identityLoader,
+		hasher,
+		passwordPolicy,
+		s.mailQueue,
+		mailTemplates,
+		s.logger,
+	)
+	rbacUC := rbacUseCase.NewRBACUseCase(s.cfg, rbacRepo, aRepo, auditUC, s.logger)
+	mailUC := mailUseCase.NewMailUseCase(s.cfg, mailTemplates, s.logger)
+	bansUC := bansUseCase.NewBansUseCase(s.cfg, bansRepo, bansRedisRepo, auditUC, s.geoIP, s.logger)
+
+	// Requests are checked against the in-memory ban list, so it has to be there before serving
+	if err = bansUC.Reload(s.ctx); err != nil {
+		return err
+	}
+	go bansUC.Watch(s.ctx)
+
+	// Init handlers
+	authHandlers := authHttp.NewAuthHandlers(s.cfg, authUC, tokenManager, s.logger)
+	tokensHandlers := tokensHttp.NewTokensHandlers(s.cfg, tokensUC, s.logger)
+	twoFactorHandlers := twoFactorHttp.NewTwoFactorHandlers(s.cfg, twoFactorUC, s.logger)
+	mailHandlers := mailHttp.NewMailHandlers(s.cfg, mailUC, s.logger)
+	sessionHandlers := sessionHttp.NewSessionHandlers(s.cfg, sessionUC, csrfManager, s.logger)
+	loginGuardHandlers := loginGuardHttp.NewLoginGuardHandlers(s.cfg, loginGuardUC, s.logger)
+	rbacHandlers := rbacHttp.NewRBACHandlers(s.cfg, rbacUC, s.logger)
+	auditHandlers := auditHttp.NewAuditHandlers(s.cfg, auditUC, s.logger)
+	bansHandlers := bansHttp.NewBansHandlers(s.cfg, bansUC, s.logger)
+	loginHistoryHandlers := loginHistoryHttp.NewLoginHistoryHandlers(s.cfg, loginHistoryUC, s.logger)
+	altsHandlers := altsHttp.NewAltsHandlers(s.cfg, altsUC, s.logger)
+
+	limiter := ratelimit.NewFallbackLimiter(
+		ratelimit.NewRedisLimiter(s.redisClient, s.cfg.RateLimit.Prefix),
+		ratelimit.NewMemoryLimiter(),
+		s.logger,
+	)
+	mw := apiMiddlewares.NewMiddlewareManager(s.cfg, tokenManager, tokensUC, sessionUC, rbacUC, bansUC, limiter, csrfManager, s.logger)
+
+	// Every c.RealIP(), and so utils.GetIPAddress, resolves the client through our proxies
+	e.IPExtractor = ipResolver.ExtractIP
+
+	e.Use(mw.RequestLoggerMiddleware)
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins:  []string{"*"},
+		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, echo.HeaderXRequestID, csrf.CSRFHeader, utils.FingerprintHeader},
+		ExposeHeaders: []string{"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", echo.HeaderRetryAfter, csrf.CSRFHeader},
+	}))
+	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
+		StackSize:         1 << 10, // 1 KB
+		DisablePrintStack: true,
+		DisableStackAll:   true,
+	}))
+	e.Use(middleware.RequestID())
+	e.Use(mw.RequestMetaMiddleware)
+	e.Use(mw.MetricsMiddleware(s.metrics))
+	e.Use(mw.BanMiddleware)
+	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
+		Level: 5,
+		Skipper: func(c echo.Context) bool {
+			return strings.Contains(c.Request().URL.Path, "swagger")
+		},
+	}))
+	e.Use(middleware.Secure())
+	e.Use(middleware.BodyLimit("2M"))
+	e.Use(mw.LanguageMiddleware)
+	e.Use(mw.CSRFMiddleware)
+	if s.cfg.Server.Debug {
+		e.Use(mw.DebugMiddleware)
+	}
+
+	wellKnown := e.Group("/.well-known")
+	authHttp.MapWellKnownRoutes(wellKnown, authHandlers)
+
+	v1 := e.Group("/api/v1")
+
+	sessionHttp.MapCSRFRoutes(v1.Group("/csrf"), sessionHandlers)
+
+	authGroup := v1.Group("/auth")
+	authHttp.MapAuthRoutes(authGroup, authHandlers, mw)
+
+	accountGroup := v1.Group("/account")
+	authHttp.MapAccountRoutes(accountGroup, authHandlers, mw)
+	tokensHttp.MapTokensRoutes(accountGroup.Group("/tokens"), tokensHandlers, mw)
+	twoFactorHttp.MapTwoFactorRoutes(accountGroup.Group("/2fa"), twoFactorHandlers, mw)
+	sessionHttp.MapSessionRoutes(accountGroup.Group("/sessions"), sessionHandlers, mw)
+	rbacHttp.MapRoleRoutes(accountGroup, rbacHandlers, mw)
+	auditHttp.MapAuditRoutes(accountGroup, auditHandlers, mw)
+	loginHistoryHttp.MapLoginHistoryRoutes(accountGroup, loginHistoryHandlers, mw)
+
+	adminGroup := v1.Group("/admin")
+	adminUsersGroup := adminGroup.Group("/users")
+	twoFactorHttp.MapTwoFactorAdminRoutes(adminUsersGroup, twoFactorHandlers, mw)
+	sessionHttp.MapSessionAdminRoutes(adminUsersGroup, sessionHandlers, mw)
+	mailHttp.MapMailAdminRoutes(adminGroup.Group("/mail"), mailHandlers, mw)
+	loginGuardHttp.MapLoginGuardAdminRoutes(adminGroup, loginGuardHandlers, mw)
+	rbacHttp.MapRoleAdminRoutes(adminGroup, rbacHandlers, mw)
+	auditHttp.MapAuditAdminRoutes(adminGroup, auditHandlers, mw)
+	bansHttp.MapBanAdminRoutes(adminGroup, bansHandlers, mw)
+	loginHistoryHttp.MapLoginHistoryAdminRoutes(adminGroup, loginHistoryHandlers, mw)
+	altsHttp.MapAltsAdminRoutes(adminGroup, altsHandlers, mw)
+
+	health := v1.Group("/health")
+	health.GET("", func(c echo.Context) error {
+		ctx, cancel := utils.GetCtxWithReqID(c)
+		defer cancel()
+
+		if err := s.db.PingContext(ctx); err != nil {
+			return c.JSON(http.StatusServiceUnavailable, utils.ResponseJSON{Code: http.StatusServiceUnavailable, Message: "mysql unavailable"})
+		}
+		if err := s.redisClient.Ping(ctx).Err(); err != nil {
+			return c.JSON(http.StatusServiceUnavailable, utils.ResponseJSON{Code: http.StatusServiceUnavailable, Message: "redis unavailable"})
+		}
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Message: "OK", Success: true})
+	})
+
+	return nil
+}
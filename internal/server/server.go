@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/pkg/geoip"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/mailer"
+	"github.com/iamaul/go-evonix-backend-api/pkg/metrics"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	maxHeaderBytes = 1 << 20
+	ctxTimeout     = 10
+)
+
+// Server ties the http router together with every long-lived dependency it owns
+type Server struct {
+	echo           *echo.Echo
+	cfg            *config.Config
+	db             *sqlx.DB
+	redisClient    *redis.Client
+	tracerProvider *tracesdk.TracerProvider
+	metrics        metrics.Metrics
+	mailQueue      *mailer.Queue
+	geoIP          *geoip.Reader
+	logger         logger.Logger
+
+	// ctx lives as long as the server, background workers stop when it is canceled
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewServer creates the api server
+func NewServer(
+	cfg *config.Config,
+	db *sqlx.DB,
+	redisClient *redis.Client,
+	tracerProvider *tracesdk.TracerProvider,
+	metrics metrics.Metrics,
+	mailQueue *mailer.Queue,
+	geoIP *geoip.Reader,
+	logger logger.Logger,
+) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Server{
+		echo:           echo.New(),
+		cfg:            cfg,
+		db:             db,
+		redisClient:    redisClient,
+		tracerProvider: tracerProvider,
+		metrics:        metrics,
+		mailQueue:      mailQueue,
+		geoIP:          geoIP,
+		logger:         logger,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+}
+
+// Run starts the api, metrics and pprof listeners and blocks until SIGINT/SIGTERM,
+// then drains in-flight requests and queued mails and releases the tracer, database and redis in order
+func (s *Server) Run() error {
+	if err := s.MapHandlers(s.echo); err != nil {
+		ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
+		defer shutdown()
+
+		s.release(ctx)
+		return err
+	}
+	go s.geoIP.Watch(s.ctx)
+
+	s.echo.HideBanner = true
+	s.echo.HidePort = true
+
+	apiServer := &http.Server{
+		Addr:           s.cfg.Server.Port,
+		ReadTimeout:    time.Second * s.cfg.Server.ReadTimeout,
+		WriteTimeout:   time.Second * s.cfg.Server.WriteTimeout,
+		MaxHeaderBytes: maxHeaderBytes,
+	}
+	metricsServer := metrics.NewServer(s.cfg.Metrics.URL)
+	pprofServer := newPprofServer(s.cfg.Server.PprofPort)
+
+	serverErr := make(chan error, 3)
+
+	go func() {
+		s.logger.Infof("Server is listening on PORT: %s", s.cfg.Server.Port)
+		if err := s.echo.StartServer(apiServer); err != nil && err != http.ErrServerClosed {
+			serverErr <- err
+		}
+	}()
+
+	go func() {
+		s.logger.Infof("Metrics server is listening on: %s", s.cfg.Metrics.URL)
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serverErr <- err
+		}
+	}()
+
+	go func() {
+		s.logger.Infof("Starting Debug Server on PORT: %s", s.cfg.Server.PprofPort)
+		if err := pprofServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serverErr <- err
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+
+	var runErr error
+	select {
+	case sig := <-quit:
+		s.logger.Infof("Received signal %s, shutting down", sig)
+	case runErr = <-serverErr:
+		s.logger.Errorf("Server error, shutting down: %s", runErr)
+	}
+
+	ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
+	defer shutdown()
+
+	if err := s.echo.Shutdown(ctx); err != nil {
+		s.logger.Errorf("Server shutdown: %s", err)
+	}
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		s.logger.Errorf("Metrics server shutdown: %s", err)
+	}
+	if err := pprofServer.Shutdown(ctx); err != nil {
+		s.logger.Errorf("Debug server shutdown: %s", err)
+	}
+	s.release(ctx)
+
+	s.logger.Info("Server exited properly")
+	return runErr
+}
+
+// release stops the background workers and closes every dependency the server owns
+func (s *Server) release(ctx context.Context) {
+	s.cancel()
+	if err := s.geoIP.Close(); err != nil {
+		s.logger.Errorf("GeoIP close: %s", err)
+	}
+	if err := s.mailQueue.Close(ctx); err != nil {
+		s.logger.Errorf("Mail queue close: %s", err)
+	}
+	if err := s.tracerProvider.Shutdown(ctx); err != nil {
+		s.logger.Errorf("Tracer provider shutdown: %s", err)
+	}
+	if err := s.db.Close(); err != nil {
+		s.logger.Errorf("MySQL close: %s", err)
+	}
+	if err := s.redisClient.Close(); err != nil {
+		s.logger.Errorf("Redis close: %s", err)
+	}
+}
+
+func newPprofServer(address string) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+}
@@ -0,0 +1,14 @@
+package session
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Session HTTP Handlers interface
+type Handlers interface {
+	List() echo.HandlerFunc
+	Revoke() echo.HandlerFunc
+	RevokeOthers() echo.HandlerFunc
+	ListByUser() echo.HandlerFunc
+	RevokeByUser() echo.HandlerFunc
+	RevokeAllByUser() echo.HandlerFunc
+	CSRFToken() echo.HandlerFunc
+}
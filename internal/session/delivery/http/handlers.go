@@ -0,0 +1,177 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/session"
+	"github.com/iamaul/go-evonix-backend-api/pkg/csrf"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Session handlers
+type sessionHandlers struct {
+	cfg         *config.Config
+	sessionUC   session.UseCase
+	csrfManager *csrf.Manager
+	logger      logger.Logger
+}
+
+// NewSessionHandlers Session handlers constructor
+func NewSessionHandlers(cfg *config.Config, sessionUC session.UseCase, csrfManager *csrf.Manager, log logger.Logger) session.Handlers {
+	return &sessionHandlers{cfg: cfg, sessionUC: sessionUC, csrfManager: csrfManager, logger: log}
+}
+
+// List Get the active sessions of the current user
+func (h *sessionHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "sessionHandlers.List")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		sessions, err := h.sessionUC.List(ctx, principal.UserID, principal.SessionID)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: sessions, Success: true})
+	}
+}
+
+// Revoke End a session of the current user
+func (h *sessionHandlers) Revoke() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "sessionHandlers.Revoke")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.sessionUC.Revoke(ctx, principal.UserID, c.Param("session_id")); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// RevokeOthers End every session of the current user except the one making the request, personal access tokens are revoked too
+func (h *sessionHandlers) RevokeOthers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "sessionHandlers.RevokeOthers")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.sessionUC.RevokeAll(ctx, principal.UserID, principal.SessionID); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// ListByUser Get the active sessions of any user, for staff
+func (h *sessionHandlers) ListByUser() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "sessionHandlers.ListByUser")
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		sessions, err := h.sessionUC.List(ctx, userID, "")
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: sessions, Success: true})
+	}
+}
+
+// RevokeByUser Kill a session of any user, for staff
+func (h *sessionHandlers) RevokeByUser() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "sessionHandlers.RevokeByUser")
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.sessionUC.Revoke(ctx, userID, c.Param("session_id")); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// RevokeAllByUser Kill every session and personal access token of any user, for staff
+func (h *sessionHandlers) RevokeAllByUser() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "sessionHandlers.RevokeAllByUser")
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.sessionUC.RevokeAll(ctx, userID, ""); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// CSRFToken Issue a csrf token bound to the session cookie, it is set as the double-submit cookie too
+func (h *sessionHandlers) CSRFToken() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "sessionHandlers.CSRFToken")
+		defer span.End()
+
+		var sessionID string
+		if cookie, err := c.Cookie(h.cfg.Session.Name); err == nil {
+			sessionID = cookie.Value
+		}
+		secret, err := h.sessionUC.CSRFSecret(ctx, sessionID)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		token, err := h.csrfManager.MakeToken(secret)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		c.SetCookie(utils.CreateCSRFCookie(h.cfg, token))
+		c.Response().Header().Set(csrf.CSRFHeader, token)
+		c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+		return c.JSON(http.StatusOK, utils.ResponseJSON{
+			Code:    http.StatusOK,
+			Result:  models.CSRFToken{Token: token, ExpiresAt: time.Now().Add(h.csrfManager.Expire()).UTC()},
+			Success: true,
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package http
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/internal/middleware"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/session"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MapSessionRoutes Map the active sessions routes of the account settings
+func MapSessionRoutes(sessionGroup *echo.Group, h session.Handlers, mw *middleware.MiddlewareManager) {
+	sessionGroup.Use(mw.AuthMiddleware)
+	sessionGroup.GET("", h.List())
+	sessionGroup.DELETE("", h.RevokeOthers())
+	sessionGroup.DELETE("/:session_id", h.Revoke())
+}
+
+// MapSessionAdminRoutes Map session routes of the staff tools
+func MapSessionAdminRoutes(adminUsersGroup *echo.Group, h session.Handlers, mw *middleware.MiddlewareManager) {
+	adminUsersGroup.GET("/:user_id/sessions", h.ListByUser(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionSessionsRead))
+	adminUsersGroup.DELETE("/:user_id/sessions", h.RevokeAllByUser(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionSessionsWrite))
+	adminUsersGroup.DELETE("/:user_id/sessions/:session_id", h.RevokeByUser(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionSessionsWrite))
+}
+
+// MapCSRFRoutes Map the csrf token route, the SPA fetches a new token after login and logout
+func MapCSRFRoutes(csrfGroup *echo.Group, h session.Handlers) {
+	csrfGroup.GET("", h.CSRFToken())
+}
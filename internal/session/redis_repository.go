@@ -0,0 +1,20 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// RedisRepository Session Redis repository interface
+type RedisRepository interface {
+	Create(ctx context.Context, session *models.Session, expire time.Duration) error
+	GetByID(ctx context.Context, sessionID string) (*models.Session, error)
+	// Touch extends the expiration of a session and, when lastSeenAt isn't nil, records it
+	Touch(ctx context.Context, session *models.Session, lastSeenAt *time.Time, expire time.Duration) error
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Session, error)
+	Delete(ctx context.Context, userID uuid.UUID, sessionID string) error
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/session"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Hash fields of a session
+const (
+	fieldUserID     = "user_id"
+	fieldIP         = "ip"
+	fieldUserAgent  = "user_agent"
+	fieldCSRFSecret = "csrf_secret"
+	fieldCreatedAt  = "created_at"
+	fieldLastSeenAt = "last_seen_at"
+)
+
+// Session redis repository
+type sessionRedisRepo struct {
+	redisClient *redis.Client
+	cfg         *config.Config
+}
+
+// NewSessionRedisRepo Session redis repository constructor
+func NewSessionRedisRepo(redisClient *redis.Client, cfg *config.Config) session.RedisRepository {
+	return &sessionRedisRepo{redisClient: redisClient, cfg: cfg}
+}
+
+// Create Save a new session and index it under its user
+func (s *sessionRedisRepo) Create(ctx context.Context, sess *models.Session, expire time.Duration) error {
+	ctx, span := otel.Tracer.Start(ctx, "sessionRedisRepo.Create")
+	defer span.End()
+
+	key := s.sessionKey(sess.ID)
+	pipe := s.redisClient.TxPipeline()
+	pipe.HSet(ctx, key,
+		fieldUserID, sess.UserID.String(),
+		fieldIP, sess.IP,
+		fieldUserAgent, sess.UserAgent,
+		fieldCSRFSecret, sess.CSRFSecret,
+		fieldCreatedAt, sess.CreatedAt.Unix(),
+		fieldLastSeenAt, sess.LastSeenAt.Unix(),
+	)
+	pipe.Expire(ctx, key, expire)
+	pipe.SAdd(ctx, s.userKey(sess.UserID), sess.ID)
+	pipe.Expire(ctx, s.userKey(sess.UserID), expire)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "sessionRedisRepo.Create.Exec")
+	}
+	return nil
+}
+
+// GetByID Get a session, a missing session is redis.Nil
+func (s *sessionRedisRepo) GetByID(ctx context.Context, sessionID string) (*models.Session, error) {
+	ctx, span := otel.Tracer.Start(ctx, "sessionRedisRepo.GetByID")
+	defer span.End()
+
+	values, err := s.redisClient.HGetAll(ctx, s.sessionKey(sessionID)).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "sessionRedisRepo.GetByID.HGetAll")
+	}
+
+	sess, err := parseSession(sessionID, values)
+	if err != nil {
+		return nil, errors.Wrap(err, "sessionRedisRepo.GetByID.parseSession")
+	}
+	return sess, nil
+}
+
+// Touch Slide the expiration of a session
+func (s *sessionRedisRepo) Touch(ctx context.Context, sess *models.Session, lastSeenAt *time.Time, expire time.Duration) error {
+	ctx, span := otel.Tracer.Start(ctx, "sessionRedisRepo.Touch")
+	defer span.End()
+
+	key := s.sessionKey(sess.ID)
+	pipe := s.redisClient.TxPipeline()
+	if lastSeenAt != nil {
+		// A session deleted meanwhile comes back without user_id and is still treated as missing
+		pipe.HSet(ctx, key, fieldLastSeenAt, lastSeenAt.Unix())
+	}
+	pipe.Expire(ctx, key, expire)
+	pipe.Expire(ctx, s.userKey(sess.UserID), expire)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "sessionRedisRepo.Touch.Exec")
+	}
+	return nil
+}
+
+// ListByUser Get the sessions of a user, dropping the expired ones from the index
+func (s *sessionRedisRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	ctx, span := otel.Tracer.Start(ctx, "sessionRedisRepo.ListByUser")
+	defer span.End()
+
+	sessionIDs, err := s.redisClient.SMembers(ctx, s.userKey(userID)).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "sessionRedisRepo.ListByUser.SMembers")
+	}
+
+	pipe := s.redisClient.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		cmds = append(cmds, pipe.HGetAll(ctx, s.sessionKey(sessionID)))
+	}
+	if len(cmds) > 0 {
+		if _, err = pipe.Exec(ctx); err != nil {
+			return nil, errors.Wrap(err, "sessionRedisRepo.ListByUser.Exec")
+		}
+	}
+
+	sessions := make([]*models.Session, 0, len(sessionIDs))
+	stale := make([]interface{}, 0)
+	for i, cmd := range cmds {
+		sess, err := parseSession(sessionIDs[i], cmd.Val())
+		if err != nil || sess.UserID != userID {
+			stale = append(stale, sessionIDs[i])
+			continue
+		}
+		sessions = append(sessions, sess)
+	}
+	if len(stale) > 0 {
+		if err = s.redisClient.SRem(ctx, s.userKey(userID), stale...).Err(); err != nil {
+			return nil, errors.Wrap(err, "sessionRedisRepo.ListByUser.SRem")
+		}
+	}
+
+	return sessions, nil
+}
+
+// Delete Delete a session and remove it from the index of its user
+func (s *sessionRedisRepo) Delete(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	ctx, span := otel.Tracer.Start(ctx, "sessionRedisRepo.Delete")
+	defer span.End()
+
+	pipe := s.redisClient.TxPipeline()
+	pipe.Del(ctx, s.sessionKey(sessionID))
+	pipe.SRem(ctx, s.userKey(userID), sessionID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "sessionRedisRepo.Delete.Exec")
+	}
+	return nil
+}
+
+func (s *sessionRedisRepo) sessionKey(sessionID string) string {
+	return s.cfg.Session.Prefix + ":" + sessionID
+}
+
+func (s *sessionRedisRepo) userKey(userID uuid.UUID) string {
+	return s.cfg.Session.Prefix + ":user:" + userID.String()
+}
+
+func parseSession(sessionID string, values map[string]string) (*models.Session, error) {
+	if values[fieldUserID] == "" {
+		return nil, redis.Nil
+	}
+
+	userID, err := uuid.Parse(values[fieldUserID])
+	if err != nil {
+		return nil, err
+	}
+	createdAt, err := strconv.ParseInt(values[fieldCreatedAt], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	lastSeenAt, err := strconv.ParseInt(values[fieldLastSeenAt], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Session{
+		ID:         sessionID,
+		UserID:     userID,
+		IP:         values[fieldIP],
+		UserAgent:  values[fieldUserAgent],
+		CSRFSecret: values[fieldCSRFSecret],
+		CreatedAt:  time.Unix(createdAt, 0).UTC(),
+		LastSeenAt: time.Unix(lastSeenAt, 0).UTC(),
+	}, nil
+}
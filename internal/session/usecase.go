@@ -0,0 +1,25 @@
+package session
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// UseCase Session UseCase interface
+type UseCase interface {
+	Create(ctx context.Context, userID uuid.UUID, sessionID, ip, userAgent string) (*models.Session, error)
+	// Touch slides the expiration of an active session of the user, an unknown session is unauthorized
+	Touch(ctx context.Context, userID uuid.UUID, sessionID string) (*models.Session, error)
+	// List returns the active sessions of a user, most recently seen first
+	List(ctx context.Context, userID uuid.UUID, currentSessionID string) ([]*models.Session, error)
+	// Revoke ends a session of the user together with its refresh token family
+	Revoke(ctx context.Context, userID uuid.UUID, sessionID string) error
+	// CSRFSecret returns the CSRF secret of a session, empty for an unknown session
+	CSRFSecret(ctx context.Context, sessionID string) (string, error)
+	// RevokeAll ends every session of the user except keepSessionID, which may be empty, and revokes
+	// every personal access token of the user
+	RevokeAll(ctx context.Context, userID uuid.UUID, keepSessionID string) error
+}
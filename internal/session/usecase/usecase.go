@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"sort"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/audit"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/session"
+	"github.com/iamaul/go-evonix-backend-api/internal/tokens"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+	"github.com/iamaul/go-evonix-backend-api/pkg/jwt"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	csrfSecretBytes = 32
+	maxUserAgentLen = 512
+	// lastSeenResolution avoids a write on every request of the same session
+	lastSeenResolution = time.Minute
+)
+
+// Session UseCase
+type sessionUC struct {
+	cfg          *config.Config
+	sessionRepo  session.RedisRepository
+	tokenManager jwt.TokenManager
+	tokensUC     tokens.UseCase
+	auditUC      audit.UseCase
+	logger       logger.Logger
+}
+
+// NewSessionUseCase Session UseCase constructor
+func NewSessionUseCase(
+	cfg *config.Config,
+	sessionRepo session.RedisRepository,
+	tokenManager jwt.TokenManager,
+	tokensUC tokens.UseCase,
+	auditUC audit.UseCase,
+	log logger.Logger,
+) session.UseCase {
+	return &sessionUC{cfg: cfg, sessionRepo: sessionRepo, tokenManager: tokenManager, tokensUC: tokensUC, auditUC: auditUC, logger: log}
+}
+
+// Create Start the session of a new login
+func (u *sessionUC) Create(ctx context.Context, userID uuid.UUID, sessionID, ip, userAgent string) (*models.Session, error) {
+	ctx, span := otel.Tracer.Start(ctx, "sessionUC.Create")
+	defer span.End()
+
+	secret := make([]byte, csrfSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if len(userAgent) > maxUserAgentLen {
+		userAgent = userAgent[:maxUserAgentLen]
+	}
+
+	now := time.Now().UTC()
+	sess := &models.Session{
+		ID:         sessionID,
+		UserID:     userID,
+		IP:         ip,
+		UserAgent:  userAgent,
+		CSRFSecret: base64.RawURLEncoding.EncodeToString(secret),
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	if err := u.sessionRepo.Create(ctx, sess, u.expire()); err != nil {
+		return nil, err
+	}
+
+	return sess, nil
+}
+
+// Touch Check that the session is still active and slide its expiration
+func (u *sessionUC) Touch(ctx context.Context, userID uuid.UUID, sessionID string) (*models.Session, error) {
+	ctx, span := otel.Tracer.Start(ctx, "sessionUC.Touch")
+	defer span.End()
+
+	sess, err := u.getOwned(ctx, userID, sessionID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, httpErr.NewUnauthorizedError(httpErr.ErrSessionExpired.Error())
+		}
+		return nil, err
+	}
+
+	var lastSeenAt *time.Time
+	if now := time.Now().UTC(); now.Sub(sess.LastSeenAt) >= lastSeenResolution {
+		lastSeenAt = &now
+		sess.LastSeenAt = now
+	}
+	if err = u.sessionRepo.Touch(ctx, sess, lastSeenAt, u.expire()); err != nil {
+		return nil, err
+	}
+
+	return sess, nil
+}
+
+// List Get the active sessions of a user
+func (u *sessionUC) List(ctx context.Context, userID uuid.UUID, currentSessionID string) ([]*models.Session, error) {
+	ctx, span := otel.Tracer.Start(ctx, "sessionUC.List")
+	defer span.End()
+
+	sessions, err := u.sessionRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sess := range sessions {
+		sess.Current = sess.ID == currentSessionID
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// Revoke End a session of the user
+func (u *sessionUC) Revoke(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	ctx, span := otel.Tracer.Start(ctx, "sessionUC.Revoke")
+	defer span.End()
+
+	if _, err := u.getOwned(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return httpErr.NewNotFoundError("session not found")
+		}
+		return err
+	}
+	if err := u.revoke(ctx, userID, sessionID); err != nil {
+		return err
+	}
+
+	u.auditUC.Record(ctx, &models.AuditLog{
+		Action:   models.AuditSessionRevoked,
+		TargetID: &userID,
+		Changes:  models.AuditChanges{"session_id": {Old: sessionID}},
+	})
+	return nil
+}
+
+// CSRFSecret Get the CSRF secret of a session, CSRF tokens of anonymous requests are bound to an empty secret
+func (u *sessionUC) CSRFSecret(ctx context.Context, sessionID string) (string, error) {
+	ctx, span := otel.Tracer.Start(ctx, "sessionUC.CSRFSecret")
+	defer span.End()
+
+	if sessionID == "" {
+		return "", nil
+	}
+	sess, err := u.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return sess.CSRFSecret, nil
+}
+
+// RevokeAll End every session of the user but one, personal access tokens go too as they would
+// outlive a compromised password or session otherwise
+func (u *sessionUC) RevokeAll(ctx context.Context, userID uuid.UUID, keepSessionID string) error {
+	ctx, span := otel.Tracer.Start(ctx, "sessionUC.RevokeAll")
+	defer span.End()
+
+	sessions, err := u.sessionRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	revoked := make([]string, 0, len(sessions))
+	for _, sess := range sessions {
+		if sess.ID == keepSessionID {
+			continue
+		}
+		if err = u.revoke(ctx, userID, sess.ID); err != nil {
+			return err
+		}
+		revoked = append(revoked, sess.ID)
+	}
+
+	revokedTokens, err := u.tokensUC.RevokeAll(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(revoked) > 0 || revokedTokens > 0 {
+		changes := models.AuditChanges{"session_ids": {Old: revoked}}
+		if revokedTokens > 0 {
+			changes["personal_access_tokens"] = models.AuditChange{Old: revokedTokens}
+		}
+		u.auditUC.Record(ctx, &models.AuditLog{
+			Action:   models.AuditSessionsRevoked,
+			TargetID: &userID,
+			Changes:  changes,
+		})
+	}
+	return nil
+}
+
+func (u *sessionUC) revoke(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	// The refresh family goes first, a session that is gone can't be refreshed back anyway
+	if err := u.tokenManager.RevokeFamily(ctx, sessionID); err != nil {
+		return err
+	}
+	return u.sessionRepo.Delete(ctx, userID, sessionID)
+}
+
+// getOwned answers redis.Nil for sessions of other users just like for missing ones
+func (u *sessionUC) getOwned(ctx context.Context, userID uuid.UUID, sessionID string) (*models.Session, error) {
+	sess, err := u.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if sess.UserID != userID {
+		return nil, redis.Nil
+	}
+
+	return sess, nil
+}
+
+func (u *sessionUC) expire() time.Duration {
+	return time.Duration(u.cfg.Session.Expire) * time.Second
+}
@@ -0,0 +1,10 @@
+package tokens
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Personal access tokens HTTP Handlers interface
+type Handlers interface {
+	Create() echo.HandlerFunc
+	List() echo.HandlerFunc
+	Revoke() echo.HandlerFunc
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/tokens"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Personal access tokens handlers
+type tokensHandlers struct {
+	cfg      *config.Config
+	tokensUC tokens.UseCase
+	logger   logger.Logger
+}
+
+// NewTokensHandlers Personal access tokens handlers constructor
+func NewTokensHandlers(cfg *config.Config, tokensUC tokens.UseCase, log logger.Logger) tokens.Handlers {
+	return &tokensHandlers{cfg: cfg, tokensUC: tokensUC, logger: log}
+}
+
+// Create a personal access token for the current user
+func (h *tokensHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "tokensHandlers.Create")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		input := &models.CreatePersonalAccessToken{}
+		if err = utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		created, err := h.tokensUC.Create(ctx, principal.UserID, input)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusCreated, utils.ResponseJSON{Code: http.StatusCreated, Result: created, Success: true})
+	}
+}
+
+// List the personal access tokens of the current user
+func (h *tokensHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "tokensHandlers.List")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		list, err := h.tokensUC.List(ctx, principal.UserID)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: list, Success: true})
+	}
+}
+
+// Revoke a personal access token of the current user
+func (h *tokensHandlers) Revoke() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "tokensHandlers.Revoke")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		tokenID, err := uuid.Parse(c.Param("token_id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.tokensUC.Revoke(ctx, principal.UserID, tokenID); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/internal/middleware"
+	"github.com/iamaul/go-evonix-backend-api/internal/tokens"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MapTokensRoutes Map personal access tokens routes, they are managed from a logged in session only
+func MapTokensRoutes(tokensGroup *echo.Group, h tokens.Handlers, mw *middleware.MiddlewareManager) {
+	tokensGroup.Use(mw.AuthMiddleware, mw.VerifiedEmailMiddleware)
+	tokensGroup.POST("", h.Create())
+	tokensGroup.GET("", h.List())
+	tokensGroup.DELETE("/:token_id", h.Revoke())
+}
@@ -0,0 +1,21 @@
+package tokens
+
+import (
+	"context"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Repository Personal access tokens MySQL repository interface
+type Repository interface {
+	Create(ctx context.Context, token *models.PersonalAccessToken) error
+	GetByID(ctx context.Context, tokenID uuid.UUID) (*models.PersonalAccessToken, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.PersonalAccessToken, error)
+	Revoke(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID) error
+	// RevokeAllByUserID revokes every active token of the user and returns how many there were
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	UpdateLastUsed(ctx context.Context, tokenID uuid.UUID, lastUsedAt time.Time) error
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/tokens"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Personal access tokens Repository
+type tokensRepo struct {
+	db *sqlx.DB
+}
+
+// NewTokensRepository Personal access tokens repository constructor
+func NewTokensRepository(db *sqlx.DB) tokens.Repository {
+	return &tokensRepo{db: db}
+}
+
+// Create a personal access token
+func (r *tokensRepo) Create(ctx context.Context, token *models.PersonalAccessToken) error {
+	ctx, span := otel.Tracer.Start(ctx, "tokensRepo.Create")
+	defer span.End()
+
+	if _, err := r.db.ExecContext(
+		ctx,
+		createToken,
+		token.ID,
+		token.UserID,
+		token.Name,
+		token.TokenHash,
+		token.Scopes,
+		token.ExpiresAt,
+		token.CreatedAt,
+	); err != nil {
+		return errors.Wrap(err, "tokensRepo.Create.ExecContext")
+	}
+
+	return nil
+}
+
+// GetByID Get a personal access token by id
+func (r *tokensRepo) GetByID(ctx context.Context, tokenID uuid.UUID) (*models.PersonalAccessToken, error) {
+	ctx, span := otel.Tracer.Start(ctx, "tokensRepo.GetByID")
+	defer span.End()
+
+	token := &models.PersonalAccessToken{}
+	if err := r.db.GetContext(ctx, token, getTokenByID, tokenID); err != nil {
+		return nil, errors.Wrap(err, "tokensRepo.GetByID.GetContext")
+	}
+
+	return token, nil
+}
+
+// ListByUserID List the active personal access tokens of a user
+func (r *tokensRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.PersonalAccessToken, error) {
+	ctx, span := otel.Tracer.Start(ctx, "tokensRepo.ListByUserID")
+	defer span.End()
+
+	list := make([]*models.PersonalAccessToken, 0)
+	if err := r.db.SelectContext(ctx, &list, listTokensByUserID, userID); err != nil {
+		return nil, errors.Wrap(err, "tokensRepo.ListByUserID.SelectContext")
+	}
+
+	return list, nil
+}
+
+// Revoke a personal access token of a user
+func (r *tokensRepo) Revoke(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID) error {
+	ctx, span := otel.Tracer.Start(ctx, "tokensRepo.Revoke")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, revokeToken, tokenID, userID)
+	if err != nil {
+		return errors.Wrap(err, "tokensRepo.Revoke.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "tokensRepo.Revoke.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "tokensRepo.Revoke.rowsAffected")
+	}
+
+	return nil
+}
+
+// RevokeAllByUserID Revoke every active personal access token of a user
+func (r *tokensRepo) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	ctx, span := otel.Tracer.Start(ctx, "tokensRepo.RevokeAllByUserID")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, revokeTokensByUserID, userID)
+	if err != nil {
+		return 0, errors.Wrap(err, "tokensRepo.RevokeAllByUserID.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "tokensRepo.RevokeAllByUserID.RowsAffected")
+	}
+
+	return rowsAffected, nil
+}
+
+// UpdateLastUsed Update the last used timestamp of a personal access token
+func (r *tokensRepo) UpdateLastUsed(ctx context.Context, tokenID uuid.UUID, lastUsedAt time.Time) error {
+	ctx, span := otel.Tracer.Start(ctx, "tokensRepo.UpdateLastUsed")
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, updateTokenLastUsed, lastUsedAt, tokenID); err != nil {
+		return errors.Wrap(err, "tokensRepo.UpdateLastUsed.ExecContext")
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package repository
+
+const (
+	createToken = `INSERT INTO personal_access_tokens (id, user_id, name, token_hash, scopes, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	getTokenByID = `SELECT id, user_id, name, token_hash, scopes, last_used_at, expires_at, revoked_at, created_at
+		FROM personal_access_tokens WHERE id = ?`
+
+	listTokensByUserID = `SELECT id, user_id, name, token_hash, scopes, last_used_at, expires_at, revoked_at, created_at
+		FROM personal_access_tokens WHERE user_id = ? AND revoked_at IS NULL ORDER BY created_at DESC`
+
+	revokeToken = `UPDATE personal_access_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND revoked_at IS NULL`
+
+	revokeTokensByUserID = `UPDATE personal_access_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = ? AND revoked_at IS NULL`
+
+	updateTokenLastUsed = `UPDATE personal_access_tokens SET last_used_at = ? WHERE id = ?`
+)
@@ -0,0 +1,20 @@
+package tokens
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/pkg/caller"
+
+	"github.com/google/uuid"
+)
+
+// UseCase Personal access tokens UseCase interface
+type UseCase interface {
+	Create(ctx context.Context, userID uuid.UUID, input *models.CreatePersonalAccessToken) (*models.CreatedPersonalAccessToken, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*models.PersonalAccessToken, error)
+	Revoke(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID) error
+	// RevokeAll revokes every token of the user and returns how many were active
+	RevokeAll(ctx context.Context, userID uuid.UUID) (int64, error)
+	Authenticate(ctx context.Context, token string) (*caller.Principal, error)
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// memoryTokensRepo keeps personal access tokens in a map
+type memoryTokensRepo struct {
+	tokens map[uuid.UUID]*models.PersonalAccessToken
+}
+
+func (r *memoryTokensRepo) Create(_ context.Context, token *models.PersonalAccessToken) error {
+	stored := *token
+	r.tokens[token.ID] = &stored
+	return nil
+}
+
+func (r *memoryTokensRepo) GetByID(_ context.Context, tokenID uuid.UUID) (*models.PersonalAccessToken, error) {
+	token, ok := r.tokens[tokenID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	stored := *token
+	return &stored, nil
+}
+
+func (r *memoryTokensRepo) ListByUserID(_ context.Context, userID uuid.UUID) ([]*models.PersonalAccessToken, error) {
+	list := make([]*models.PersonalAccessToken, 0)
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			list = append(list, token)
+		}
+	}
+	return list, nil
+}
+
+func (r *memoryTokensRepo) Revoke(_ context.Context, userID uuid.UUID, tokenID uuid.UUID) error {
+	token, ok := r.tokens[tokenID]
+	if !ok || token.UserID != userID || token.RevokedAt != nil {
+		return sql.ErrNoRows
+	}
+	now := time.Now()
+	token.RevokedAt = &now
+	return nil
+}
+
+func (r *memoryTokensRepo) RevokeAllByUserID(_ context.Context, userID uuid.UUID) (int64, error) {
+	var revoked int64
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (r *memoryTokensRepo) UpdateLastUsed(_ context.Context, tokenID uuid.UUID, lastUsedAt time.Time) error {
+	r.tokens[tokenID].LastUsedAt = &lastUsedAt
+	return nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryTokensRepo{tokens: make(map[uuid.UUID]*models.PersonalAccessToken)}
+	u := &tokensUC{tokensRepo: repo}
+	userID := uuid.New()
+
+	create := func(expiresInDays int) *models.CreatedPersonalAccessToken {
+		created, err := u.Create(ctx, userID, &models.CreatePersonalAccessToken{
+			Name:          "bot",
+			Scopes:        []string{models.ScopeProfileRead, models.ScopeProfileRead},
+			ExpiresInDays: expiresInDays,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return created
+	}
+
+	valid := create(0)
+	revoked := create(0)
+	if err := u.Revoke(ctx, userID, revoked.ID); err != nil {
+		t.Fatal(err)
+	}
+	expired := create(1)
+	past := time.Now().Add(-time.Hour)
+	repo.tokens[expired.ID].ExpiresAt = &past
+
+	secret := valid.Token[strings.LastIndex(valid.Token, tokenSeparator)+1:]
+	if stored := repo.tokens[valid.ID].TokenHash; stored != digestSecret(secret) {
+		t.Fatalf("stored hash = %q, want the digest of the secret", stored)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid", valid.Token, false},
+		{"wrong secret", valid.Token + "x", true},
+		{"secret of another token", models.PersonalAccessTokenPrefix + revoked.ID.String() + tokenSeparator + secret, true},
+		{"unknown id", models.PersonalAccessTokenPrefix + uuid.NewString() + tokenSeparator + secret, true},
+		{"revoked", revoked.Token, true},
+		{"expired", expired.Token, true},
+		{"no secret", models.PersonalAccessTokenPrefix + valid.ID.String() + tokenSeparator, true},
+		{"malformed id", models.PersonalAccessTokenPrefix + "bot" + tokenSeparator + secret, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := u.Authenticate(ctx, tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, httpErr.ErrInvalidPersonalAccessToken) {
+					t.Fatalf("Authenticate() error = %v, want %v", err, httpErr.ErrInvalidPersonalAccessToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if principal.UserID != userID || len(principal.Scopes) != 1 || principal.Scopes[0] != models.ScopeProfileRead {
+				t.Fatalf("Authenticate() = %+v, want the user with the deduplicated scopes", principal)
+			}
+		})
+	}
+}
+
+func TestRevokeAll(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryTokensRepo{tokens: make(map[uuid.UUID]*models.PersonalAccessToken)}
+	u := &tokensUC{tokensRepo: repo}
+	userID, otherID := uuid.New(), uuid.New()
+	input := &models.CreatePersonalAccessToken{Name: "bot", Scopes: []string{models.ScopeStatsRead}}
+
+	var userTokens []string
+	for i := 0; i < 2; i++ {
+		created, err := u.Create(ctx, userID, input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		userTokens = append(userTokens, created.Token)
+	}
+	other, err := u.Create(ctx, otherID, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revoked, err := u.RevokeAll(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked != 2 {
+		t.Fatalf("RevokeAll() = %d, want 2", revoked)
+	}
+	for _, token := range userTokens {
+		if _, err = u.Authenticate(ctx, token); !errors.Is(err, httpErr.ErrInvalidPersonalAccessToken) {
+			t.Fatalf("Authenticate() of a revoked token error = %v, want %v", err, httpErr.ErrInvalidPersonalAccessToken)
+		}
+	}
+	if _, err = u.Authenticate(ctx, other.Token); err != nil {
+		t.Fatalf("Authenticate() of another user's token error = %v", err)
+	}
+}
@@ -0,0 +1,13 @@
+package twofactor
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Two-factor authentication HTTP Handlers interface
+type Handlers interface {
+	Status() echo.HandlerFunc
+	Enroll() echo.HandlerFunc
+	Confirm() echo.HandlerFunc
+	Disable() echo.HandlerFunc
+	RegenerateRecoveryCodes() echo.HandlerFunc
+	Reset() echo.HandlerFunc
+}
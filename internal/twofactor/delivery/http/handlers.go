@@ -0,0 +1,160 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/twofactor"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+	"github.com/iamaul/go-evonix-backend-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Two-factor authentication handlers
+type twoFactorHandlers struct {
+	cfg         *config.Config
+	twoFactorUC twofactor.UseCase
+	logger      logger.Logger
+}
+
+// NewTwoFactorHandlers Two-factor authentication handlers constructor
+func NewTwoFactorHandlers(cfg *config.Config, twoFactorUC twofactor.UseCase, log logger.Logger) twofactor.Handlers {
+	return &twoFactorHandlers{cfg: cfg, twoFactorUC: twoFactorUC, logger: log}
+}
+
+// Status Get the two-factor state of the current user
+func (h *twoFactorHandlers) Status() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "twoFactorHandlers.Status")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		status, err := h.twoFactorUC.Status(ctx, principal.UserID)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: status, Success: true})
+	}
+}
+
+// Enroll Start the enrollment of the current user
+func (h *twoFactorHandlers) Enroll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "twoFactorHandlers.Enroll")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		enrollment, err := h.twoFactorUC.Enroll(ctx, principal.UserID)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: enrollment, Success: true})
+	}
+}
+
+// Confirm Confirm the enrollment of the current user
+func (h *twoFactorHandlers) Confirm() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "twoFactorHandlers.Confirm")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		input := &models.TOTPCodeRequest{}
+		if err = utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		codes, err := h.twoFactorUC.Confirm(ctx, principal.UserID, input.Code)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: codes, Success: true})
+	}
+}
+
+// Disable Disable two-factor authentication of the current user
+func (h *twoFactorHandlers) Disable() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "twoFactorHandlers.Disable")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		input := &models.TwoFactorCodeRequest{}
+		if err = utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.twoFactorUC.Disable(ctx, principal.UserID, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// RegenerateRecoveryCodes Replace the recovery codes of the current user
+func (h *twoFactorHandlers) RegenerateRecoveryCodes() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "twoFactorHandlers.RegenerateRecoveryCodes")
+		defer span.End()
+
+		principal, err := utils.GetPrincipalFromCtx(ctx)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		input := &models.TOTPCodeRequest{}
+		if err = utils.ReadRequest(c, input); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		codes, err := h.twoFactorUC.RegenerateRecoveryCodes(ctx, principal.UserID, input.Code)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ResponseJSON{Code: http.StatusOK, Result: codes, Success: true})
+	}
+}
+
+// Reset Remove two-factor authentication of any user, for staff
+func (h *twoFactorHandlers) Reset() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := otel.Tracer.Start(utils.GetRequestCtx(c), "twoFactorHandlers.Reset")
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.twoFactorUC.Reset(ctx, userID); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
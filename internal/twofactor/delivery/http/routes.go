@@ -0,0 +1,24 @@
+package http
+
+import (
+	"github.com/iamaul/go-evonix-backend-api/internal/middleware"
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/twofactor"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MapTwoFactorRoutes Map two-factor authentication routes of the account settings
+func MapTwoFactorRoutes(twoFactorGroup *echo.Group, h twofactor.Handlers, mw *middleware.MiddlewareManager) {
+	twoFactorGroup.Use(mw.AuthMiddleware, mw.VerifiedEmailMiddleware)
+	twoFactorGroup.GET("", h.Status())
+	twoFactorGroup.POST("/enroll", h.Enroll())
+	twoFactorGroup.POST("/confirm", h.Confirm())
+	twoFactorGroup.POST("/disable", h.Disable())
+	twoFactorGroup.POST("/recovery-codes", h.RegenerateRecoveryCodes())
+}
+
+// MapTwoFactorAdminRoutes Map two-factor authentication routes of the staff tools
+func MapTwoFactorAdminRoutes(adminUsersGroup *echo.Group, h twofactor.Handlers, mw *middleware.MiddlewareManager) {
+	adminUsersGroup.DELETE("/:user_id/2fa", h.Reset(), mw.AuthMiddleware, mw.PermissionMiddleware(models.PermissionTwoFactorReset))
+}
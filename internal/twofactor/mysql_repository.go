@@ -0,0 +1,23 @@
+package twofactor
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Repository Two-factor authentication MySQL repository interface
+type Repository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*models.TwoFactor, error)
+	SavePending(ctx context.Context, userID uuid.UUID, secret string) error
+	Enable(ctx context.Context, userID uuid.UUID, step int64) error
+	// UseStep records the TOTP step as used and reports false if it (or a later one) already was
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	// UseRecoveryCode consumes an unused recovery code and reports whether one matched
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+	"github.com/iamaul/go-evonix-backend-api/internal/twofactor"
+	"github.com/iamaul/go-evonix-backend-api/pkg/otel"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Two-factor authentication Repository
+type twoFactorRepo struct {
+	db *sqlx.DB
+}
+
+// NewTwoFactorRepository Two-factor authentication Repository constructor
+func NewTwoFactorRepository(db *sqlx.DB) twofactor.Repository {
+	return &twoFactorRepo{db: db}
+}
+
+// GetByUserID Get the two-factor enrollment of a user
+func (r *twoFactorRepo) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.TwoFactor, error) {
+	ctx, span := otel.Tracer.Start(ctx, "twoFactorRepo.GetByUserID")
+	defer span.End()
+
+	tf := &models.TwoFactor{}
+	if err := r.db.GetContext(ctx, tf, getTwoFactorByUserID, userID); err != nil {
+		return nil, errors.Wrap(err, "twoFactorRepo.GetByUserID.GetContext")
+	}
+
+	return tf, nil
+}
+
+// SavePending Save a new secret unless two-factor authentication is already enabled
+func (r *twoFactorRepo) SavePending(ctx context.Context, userID uuid.UUID, secret string) error {
+	ctx, span := otel.Tracer.Start(ctx, "twoFactorRepo.SavePending")
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, saveTwoFactorPending, userID, secret); err != nil {
+		return errors.Wrap(err, "twoFactorRepo.SavePending.ExecContext")
+	}
+
+	return nil
+}
+
+// Enable Confirm a pending enrollment
+func (r *twoFactorRepo) Enable(ctx context.Context, userID uuid.UUID, step int64) error {
+	ctx, span := otel.Tracer.Start(ctx, "twoFactorRepo.Enable")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, enableTwoFactor, step, userID)
+	if err != nil {
+		return errors.Wrap(err, "twoFactorRepo.Enable.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "twoFactorRepo.Enable.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "twoFactorRepo.Enable.rowsAffected")
+	}
+
+	return nil
+}
+
+// UseStep Record a TOTP step as used
+func (r *twoFactorRepo) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	ctx, span := otel.Tracer.Start(ctx, "twoFactorRepo.UseStep")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, useTwoFactorStep, step, userID, step)
+	if err != nil {
+		return false, errors.Wrap(err, "twoFactorRepo.UseStep.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "twoFactorRepo.UseStep.RowsAffected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Delete Delete the enrollment and the recovery codes of a user
+func (r *twoFactorRepo) Delete(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := otel.Tracer.Start(ctx, "twoFactorRepo.Delete")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "twoFactorRepo.Delete.BeginTxx")
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	if _, err = tx.ExecContext(ctx, deleteRecoveryCodes, userID); err != nil {
+		return errors.Wrap(err, "twoFactorRepo.Delete.deleteRecoveryCodes")
+	}
+	if _, err = tx.ExecContext(ctx, deleteTwoFactor, userID); err != nil {
+		return errors.Wrap(err, "twoFactorRepo.Delete.deleteTwoFactor")
+	}
+
+	return errors.Wrap(tx.Commit(), "twoFactorRepo.Delete.Commit")
+}
+
+// ReplaceRecoveryCodes Replace every recovery code of a user
+func (r *twoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	ctx, span := otel.Tracer.Start(ctx, "twoFactorRepo.ReplaceRecoveryCodes")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "twoFactorRepo.ReplaceRecoveryCodes.BeginTxx")
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	if _, err = tx.ExecContext(ctx, deleteRecoveryCodes, userID); err != nil {
+		return errors.Wrap(err, "twoFactorRepo.ReplaceRecoveryCodes.deleteRecoveryCodes")
+	}
+	for _, codeHash := range codeHashes {
+		if _, err = tx.ExecContext(ctx, createRecoveryCode, userID, codeHash); err != nil {
+			return errors.Wrap(err, "twoFactorRepo.ReplaceRecoveryCodes.createRecoveryCode")
+		}
+	}
+
+	return errors.Wrap(tx.Commit(), "twoFactorRepo.ReplaceRecoveryCodes.Commit")
+}
+
+// UseRecoveryCode Consume an unused recovery code
+func (r *twoFactorRepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	ctx, span := otel.Tracer.Start(ctx, "twoFactorRepo.UseRecoveryCode")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, useRecoveryCode, userID, codeHash)
+	if err != nil {
+		return false, errors.Wrap(err, "twoFactorRepo.UseRecoveryCode.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "twoFactorRepo.UseRecoveryCode.RowsAffected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// CountUnusedRecoveryCodes Count the recovery codes a user has left
+func (r *twoFactorRepo) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, span := otel.Tracer.Start(ctx, "twoFactorRepo.CountUnusedRecoveryCodes")
+	defer span.End()
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, countUnusedRecoveryCodes, userID); err != nil {
+		return 0, errors.Wrap(err, "twoFactorRepo.CountUnusedRecoveryCodes.GetContext")
+	}
+
+	return count, nil
+}
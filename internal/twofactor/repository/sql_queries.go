@@ -0,0 +1,27 @@
+package repository
+
+const (
+	getTwoFactorByUserID = `SELECT user_id, secret, last_used_step, enabled_at, created_at
+		FROM user_two_factor WHERE user_id = ?`
+
+	saveTwoFactorPending = `INSERT INTO user_two_factor (user_id, secret, last_used_step, enabled_at, created_at)
+		VALUES (?, ?, 0, NULL, CURRENT_TIMESTAMP)
+		ON DUPLICATE KEY UPDATE secret = IF(enabled_at IS NULL, VALUES(secret), secret),
+			created_at = IF(enabled_at IS NULL, VALUES(created_at), created_at)`
+
+	enableTwoFactor = `UPDATE user_two_factor SET enabled_at = CURRENT_TIMESTAMP, last_used_step = ?
+		WHERE user_id = ? AND enabled_at IS NULL`
+
+	useTwoFactorStep = `UPDATE user_two_factor SET last_used_step = ? WHERE user_id = ? AND last_used_step < ?`
+
+	deleteTwoFactor = `DELETE FROM user_two_factor WHERE user_id = ?`
+
+	deleteRecoveryCodes = `DELETE FROM user_recovery_codes WHERE user_id = ?`
+
+	createRecoveryCode = `INSERT INTO user_recovery_codes (user_id, code_hash) VALUES (?, ?)`
+
+	useRecoveryCode = `UPDATE user_recovery_codes SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = ? AND code_hash = ? AND used_at IS NULL LIMIT 1`
+
+	countUnusedRecoveryCodes = `SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = ? AND used_at IS NULL`
+)
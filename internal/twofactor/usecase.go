@@ -0,0 +1,24 @@
+package twofactor
+
+import (
+	"context"
+
+	"github.com/iamaul/go-evonix-backend-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// UseCase Two-factor authentication UseCase interface
+type UseCase interface {
+	Status(ctx context.Context, userID uuid.UUID) (*models.TwoFactorStatus, error)
+	// Enroll starts (or restarts) an enrollment, it has no effect on logins until confirmed
+	Enroll(ctx context.Context, userID uuid.UUID) (*models.TwoFactorEnrollment, error)
+	Confirm(ctx context.Context, userID uuid.UUID, code string) (*models.RecoveryCodes, error)
+	Disable(ctx context.Context, userID uuid.UUID, input *models.TwoFactorCodeRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*models.RecoveryCodes, error)
+	IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error)
+	// Verify checks a TOTP code or consumes a recovery code
+	Verify(ctx context.Context, userID uuid.UUID, input *models.TwoFactorCodeRequest) error
+	// Reset removes the enrollment without a code, for staff helping a locked out player
+	Reset(ctx context.Context, userID uuid.UUID) error
+}
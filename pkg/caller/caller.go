@@ -0,0 +1,59 @@
+package caller
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ways a principal can authenticate
+const (
+	AuthTypeJWT                 = "jwt"
+	AuthTypePersonalAccessToken = "personal_access_token"
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	UserID     uuid.UUID `json:"user_id"`
+	AuthType   string    `json:"auth_type"`
+	SessionID  string    `json:"session_id,omitempty"`
+	TokenID    string    `json:"-"`
+	ExpiresAt  time.Time `json:"-"`
+	AdminLevel int       `json:"admin_level"`
+	Roles      []string  `json:"roles,omitempty"`
+	// EmailVerified is only known for JWT sessions, personal access tokens are read-only anyway
+	EmailVerified bool `json:"email_verified"`
+	// Scopes limit a personal access token, a JWT session is not limited by scopes
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// HasScopes reports whether the principal may use every given scope
+func (p *Principal) HasScopes(scopes ...string) bool {
+	if p.AuthType != AuthTypePersonalAccessToken {
+		return true
+	}
+	for _, scope := range scopes {
+		if !p.hasScope(scope) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Principal) hasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestMeta describes the request an action came from, it is recorded with audit log entries.
+// Fingerprint is the browser fingerprint the UCP frontend sends, it may be empty.
+type RequestMeta struct {
+	IP          string
+	UserAgent   string
+	RequestID   string
+	Fingerprint string
+}
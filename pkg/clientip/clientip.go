@@ -0,0 +1,157 @@
+package clientip
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	HeaderXForwardedFor  = "X-Forwarded-For"
+	HeaderXRealIP        = "X-Real-IP"
+	HeaderCFConnectingIP = "CF-Connecting-IP"
+)
+
+// Resolver finds the ip address of the client behind our reverse proxies. Forwarding headers
+// are only read when the request comes from a trusted proxy, X-Forwarded-For is walked from the
+// right and the first hop that isn't a trusted proxy is the client. X-Real-IP and CF-Connecting-IP
+// hold a single address and are taken as-is, so only list them when the proxy overwrites them.
+type Resolver struct {
+	trusted []*net.IPNet
+	headers []string
+}
+
+// NewResolver creates a Resolver, trusted proxies are CIDRs or single addresses
+func NewResolver(cfg config.ClientIP) (*Resolver, error) {
+	r := &Resolver{}
+	for _, proxy := range cfg.TrustedProxies {
+		network, err := parseNetwork(proxy)
+		if err != nil {
+			return nil, err
+		}
+		r.trusted = append(r.trusted, network)
+	}
+	for _, header := range cfg.Headers {
+		header = http.CanonicalHeaderKey(strings.TrimSpace(header))
+		switch header {
+		case http.CanonicalHeaderKey(HeaderXForwardedFor), http.CanonicalHeaderKey(HeaderXRealIP), http.CanonicalHeaderKey(HeaderCFConnectingIP):
+			r.headers = append(r.headers, header)
+		default:
+			return nil, errors.Errorf("client ip header %q is not supported", header)
+		}
+	}
+	return r, nil
+}
+
+// ExtractIP Get the normalized client ip of the request, it can be used as the echo IPExtractor
+func (r *Resolver) ExtractIP(req *http.Request) string {
+	remote := ParseIP(req.RemoteAddr)
+	if remote == nil {
+		return req.RemoteAddr
+	}
+	if !r.isTrusted(remote) {
+		return remote.String()
+	}
+
+	for _, header := range r.headers {
+		var ip net.IP
+		if header == http.CanonicalHeaderKey(HeaderXForwardedFor) {
+			ip = r.fromForwardedFor(req.Header.Values(header))
+		} else {
+			ip = ParseIP(req.Header.Get(header))
+		}
+		if ip != nil {
+			return ip.String()
+		}
+	}
+
+	return remote.String()
+}
+
+// fromForwardedFor walks the hops right to left, a malformed hop ends the walk since
+// nothing left of it can be trusted, the header is ignored then
+func (r *Resolver) fromForwardedFor(values []string) net.IP {
+	var hops []string
+	for _, value := range values {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+
+	var leftmost net.IP
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := ParseIP(hops[i])
+		if ip == nil {
+			return nil
+		}
+		if !r.isTrusted(ip) {
+			return ip
+		}
+		leftmost = ip
+	}
+
+	// Every hop is one of our proxies, the request came from inside
+	return leftmost
+}
+
+func (r *Resolver) isTrusted(ip net.IP) bool {
+	for _, network := range r.trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseIP parses an address as it appears in RemoteAddr or a forwarding header, with an optional
+// port, brackets or zone. IPv4-mapped IPv6 addresses are turned into IPv4 so both forms match.
+func ParseIP(value string) net.IP {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	if zone := strings.IndexByte(value, '%'); zone != -1 {
+		value = value[:zone]
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip
+}
+
+// Normalize Get the canonical form of an address, it is empty when the address is invalid
+func Normalize(value string) string {
+	ip := ParseIP(value)
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+func parseNetwork(value string) (*net.IPNet, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "/") {
+		ip := ParseIP(value)
+		if ip == nil {
+			return nil, errors.Errorf("invalid trusted proxy %q", value)
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}, nil
+	}
+
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid trusted proxy %q", value)
+	}
+	return network, nil
+}
@@ -0,0 +1,181 @@
+package clientip
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+)
+
+func newTestResolver(t *testing.T, headers ...string) *Resolver {
+	t.Helper()
+	r, err := NewResolver(config.ClientIP{
+		TrustedProxies: []string{"10.0.0.0/8", "172.16.0.1", "2001:db8::/32"},
+		Headers:        headers,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestExtractIP(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		remote  string
+		set     map[string][]string
+		want    string
+	}{
+		{
+			name:   "direct client",
+			remote: "203.0.113.7:51234",
+			want:   "203.0.113.7",
+		},
+		{
+			name:   "untrusted peer can't spoof X-Forwarded-For",
+			remote: "203.0.113.7:51234",
+			set:    map[string][]string{HeaderXForwardedFor: {"198.51.100.1"}},
+			want:   "203.0.113.7",
+		},
+		{
+			name:   "client behind one proxy",
+			remote: "10.0.0.2:443",
+			set:    map[string][]string{HeaderXForwardedFor: {"198.51.100.1"}},
+			want:   "198.51.100.1",
+		},
+		{
+			name:   "spoofed hop left of the real client is ignored",
+			remote: "10.0.0.2:443",
+			set:    map[string][]string{HeaderXForwardedFor: {"1.1.1.1, 198.51.100.1"}},
+			want:   "198.51.100.1",
+		},
+		{
+			name:   "trusted hops are skipped from the right",
+			remote: "10.0.0.2:443",
+			set:    map[string][]string{HeaderXForwardedFor: {"1.1.1.1, 198.51.100.1, 172.16.0.1, 10.9.9.9"}},
+			want:   "198.51.100.1",
+		},
+		{
+			name:   "spoofed trusted address left of the client is not reached",
+			remote: "10.0.0.2:443",
+			set:    map[string][]string{HeaderXForwardedFor: {"10.1.1.1, 198.51.100.1"}},
+			want:   "198.51.100.1",
+		},
+		{
+			name:   "hops split over several headers",
+			remote: "10.0.0.2:443",
+			set:    map[string][]string{HeaderXForwardedFor: {"1.1.1.1", "198.51.100.1, 10.0.0.3"}},
+			want:   "198.51.100.1",
+		},
+		{
+			name:   "malformed hop ends the walk and the header is ignored",
+			remote: "10.0.0.2:443",
+			set:    map[string][]string{HeaderXForwardedFor: {"198.51.100.1, not-an-ip, 10.0.0.3"}},
+			want:   "10.0.0.2",
+		},
+		{
+			name:   "every hop trusted",
+			remote: "10.0.0.2:443",
+			set:    map[string][]string{HeaderXForwardedFor: {"10.0.0.5, 10.0.0.3"}},
+			want:   "10.0.0.5",
+		},
+		{
+			name:   "hops with ports, brackets and mapped addresses",
+			remote: "[2001:db8::1]:443",
+			set:    map[string][]string{HeaderXForwardedFor: {"[2001:db9::5]:1234, ::ffff:10.0.0.3"}},
+			want:   "2001:db9::5",
+		},
+		{
+			name:   "no forwarding header",
+			remote: "10.0.0.2:443",
+			want:   "10.0.0.2",
+		},
+		{
+			name:    "X-Real-IP is only read when configured",
+			remote:  "10.0.0.2:443",
+			set:     map[string][]string{HeaderXRealIP: {"198.51.100.1"}},
+			want:    "10.0.0.2",
+			headers: []string{HeaderXForwardedFor},
+		},
+		{
+			name:    "headers are tried in order",
+			remote:  "10.0.0.2:443",
+			set:     map[string][]string{HeaderCFConnectingIP: {"198.51.100.9"}, HeaderXForwardedFor: {"198.51.100.1"}},
+			want:    "198.51.100.9",
+			headers: []string{HeaderCFConnectingIP, HeaderXForwardedFor},
+		},
+		{
+			name:    "invalid CF-Connecting-IP falls through to the next header",
+			remote:  "10.0.0.2:443",
+			set:     map[string][]string{HeaderCFConnectingIP: {"garbage"}, HeaderXForwardedFor: {"198.51.100.1"}},
+			want:    "198.51.100.1",
+			headers: []string{HeaderCFConnectingIP, HeaderXForwardedFor},
+		},
+		{
+			name:   "IPv4-mapped remote address",
+			remote: "[::ffff:203.0.113.7]:51234",
+			want:   "203.0.113.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := tt.headers
+			if headers == nil {
+				headers = []string{HeaderXForwardedFor}
+			}
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.RemoteAddr = tt.remote
+			for header, values := range tt.set {
+				for _, value := range values {
+					req.Header.Add(header, value)
+				}
+			}
+
+			if got := newTestResolver(t, headers...).ExtractIP(req); got != tt.want {
+				t.Fatalf("ExtractIP() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewResolverRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.ClientIP
+	}{
+		{"invalid proxy", config.ClientIP{TrustedProxies: []string{"not-an-ip"}}},
+		{"invalid cidr", config.ClientIP{TrustedProxies: []string{"10.0.0.0/33"}}},
+		{"unsupported header", config.ClientIP{Headers: []string{"Forwarded"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewResolver(tt.cfg); err == nil {
+				t.Fatal("accepted")
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"203.0.113.7":        "203.0.113.7",
+		" 203.0.113.7:80 ":   "203.0.113.7",
+		"::ffff:203.0.113.7": "203.0.113.7",
+		"[2001:DB8::1]:443":  "2001:db8::1",
+		"fe80::1%eth0":       "fe80::1",
+		"not-an-ip":          "",
+		"":                   "",
+	}
+
+	for value, want := range tests {
+		if got := Normalize(value); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
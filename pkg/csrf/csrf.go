@@ -1,30 +1,86 @@
 package csrf
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
-	"io"
+	"encoding/binary"
+	"strings"
+	"time"
 
-	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+
+	"github.com/pkg/errors"
 )
 
 const (
 	CSRFHeader = "X-CSRF-Token"
-	// 32 bytes
-	csrfSalt = "KbWaoi5xtDC3GEfBa9ovQdzOzXsuVU9I"
+
+	nonceBytes     = 16
+	timestampBytes = 8
+	minSecretBytes = 32
 )
 
-func MakeToken(sid string, logger logger.Logger) string {
-	hash := sha256.New()
-	_, err := io.WriteString(hash, csrfSalt+sid)
-	if err != nil {
-		logger.Errorf("make CSRF token", err)
+// Manager issues and checks signed double-submit CSRF tokens. A token is
+// base64(issued at + nonce).base64(HMAC-SHA256), the MAC also covers the CSRF secret
+// of the session so a token can't be reused once the session is gone.
+type Manager struct {
+	secret []byte
+	expire time.Duration
+}
+
+// NewManager creates a Manager, the secret must be at least 32 bytes long
+func NewManager(secret string, expire time.Duration) (*Manager, error) {
+	if len(secret) < minSecretBytes {
+		return nil, errors.Errorf("csrf secret must be at least %d bytes long", minSecretBytes)
+	}
+	return &Manager{secret: []byte(secret), expire: expire}, nil
+}
+
+// Expire returns how long a token stays valid
+func (m *Manager) Expire() time.Duration {
+	return m.expire
+}
+
+// MakeToken issues a token bound to the CSRF secret of the session, empty for anonymous requests
+func (m *Manager) MakeToken(sessionSecret string) (string, error) {
+	payload := make([]byte, timestampBytes+nonceBytes)
+	binary.BigEndian.PutUint64(payload, uint64(time.Now().Unix()))
+	if _, err := rand.Read(payload[timestampBytes:]); err != nil {
+		return "", errors.Wrap(err, "csrf.MakeToken.Read")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(m.sign(payload, sessionSecret)), nil
+}
+
+// ValidateToken checks the signature and age of a token, it returns ErrWrongCSRFToken or ErrExpiredCSRFError
+func (m *Manager) ValidateToken(token, sessionSecret string) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return httpErr.ErrWrongCSRFToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || len(payload) != timestampBytes+nonceBytes {
+		return httpErr.ErrWrongCSRFToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, m.sign(payload, sessionSecret)) {
+		return httpErr.ErrWrongCSRFToken
+	}
+
+	issuedAt := time.Unix(int64(binary.BigEndian.Uint64(payload)), 0)
+	if time.Since(issuedAt) > m.expire {
+		return httpErr.ErrExpiredCSRFError
 	}
-	token := base64.RawStdEncoding.EncodeToString(hash.Sum(nil))
-	return token
+	return nil
 }
 
-func ValidateToken(token string, sid string, logger logger.Logger) bool {
-	trueToken := MakeToken(sid, logger)
-	return token == trueToken
+func (m *Manager) sign(payload []byte, sessionSecret string) []byte {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(sessionSecret))
+	mac.Write([]byte{0})
+	mac.Write(payload)
+	return mac.Sum(nil)
 }
@@ -0,0 +1,89 @@
+package csrf
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// tokenIssuedAt signs a token as if MakeToken had run at issuedAt
+func tokenIssuedAt(m *Manager, issuedAt time.Time, sessionSecret string) string {
+	payload := make([]byte, timestampBytes+nonceBytes)
+	binary.BigEndian.PutUint64(payload, uint64(issuedAt.Unix()))
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(m.sign(payload, sessionSecret))
+}
+
+func TestNewManagerRejectsShortSecret(t *testing.T) {
+	if _, err := NewManager(testSecret[:minSecretBytes-1], time.Hour); err == nil {
+		t.Fatal("short secret accepted")
+	}
+}
+
+func TestValidateToken(t *testing.T) {
+	m, err := NewManager(testSecret, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewManager(strings.Repeat("x", minSecretBytes), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, err := m.MakeToken("session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(valid, ".")
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	payload[timestampBytes] ^= 0xff
+	tamperedPayload := base64.RawURLEncoding.EncodeToString(payload) + "." + parts[1]
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	signature[0] ^= 0xff
+	tamperedSignature := parts[0] + "." + base64.RawURLEncoding.EncodeToString(signature)
+
+	tests := []struct {
+		name          string
+		token         string
+		sessionSecret string
+		want          error
+	}{
+		{"valid", valid, "session", nil},
+		{"other session", valid, "other", httpErr.ErrWrongCSRFToken},
+		{"anonymous token for a session", mustMakeToken(t, m, ""), "session", httpErr.ErrWrongCSRFToken},
+		{"tampered payload", tamperedPayload, "session", httpErr.ErrWrongCSRFToken},
+		{"tampered signature", tamperedSignature, "session", httpErr.ErrWrongCSRFToken},
+		{"signed with another secret", mustMakeToken(t, other, "session"), "session", httpErr.ErrWrongCSRFToken},
+		{"no separator", parts[0] + parts[1], "session", httpErr.ErrWrongCSRFToken},
+		{"extra part", valid + ".x", "session", httpErr.ErrWrongCSRFToken},
+		{"short payload", base64.RawURLEncoding.EncodeToString([]byte("short")) + "." + parts[1], "session", httpErr.ErrWrongCSRFToken},
+		{"invalid base64", "!!." + parts[1], "session", httpErr.ErrWrongCSRFToken},
+		{"empty", "", "session", httpErr.ErrWrongCSRFToken},
+		{"still fresh", tokenIssuedAt(m, time.Now().Add(-59*time.Minute), "session"), "session", nil},
+		{"expired", tokenIssuedAt(m, time.Now().Add(-61*time.Minute), "session"), "session", httpErr.ErrExpiredCSRFError},
+		// The age is only looked at once the signature holds, a forged old token is just wrong
+		{"expired and tampered", tokenIssuedAt(m, time.Now().Add(-61*time.Minute), "other"), "session", httpErr.ErrWrongCSRFToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := m.ValidateToken(tt.token, tt.sessionSecret); err != tt.want {
+				t.Fatalf("ValidateToken() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func mustMakeToken(t *testing.T, m *Manager, sessionSecret string) string {
+	t.Helper()
+	token, err := m.MakeToken(sessionSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
@@ -20,7 +20,7 @@ const (
 var conn *sqlx.DB
 
 func NewMysqlDB(c *config.Config) (*sqlx.DB, error) {
-	dataSourceName := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s",
+	dataSourceName := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true",
 		c.Mysql.MysqlUser,
 		c.Mysql.MysqlPassword,
 		c.Mysql.MysqlHost,
@@ -6,8 +6,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 const (
@@ -21,6 +25,9 @@ const (
 	BadQueryParams     = "invalid query params"
 )
 
+// mysqlDuplicateEntry is the MySQL error number of a unique key violation
+const mysqlDuplicateEntry = 1062
+
 var (
 	ErrBadRequest            = errors.New("bad request")
 	ErrInvalidPassword       = errors.New("invalid password")
@@ -37,12 +44,41 @@ var (
 	ErrInternalServerError   = errors.New("internal server error")
 	ErrRequestTimeoutError   = errors.New("request timeout")
 	ErrExistsEmailError      = errors.New("user with given email already exists")
+	ErrExistsUsernameError   = errors.New("user with given username already exists")
+	ErrAlreadyExists         = errors.New("already exists")
 	ErrInvalidJWTToken       = errors.New("invalid jwt token")
 	ErrExpiredJWTToken       = errors.New("expired jwt token")
 	ErrInvalidJWTClaims      = errors.New("invalid jwt claims")
+	ErrRevokedJWTToken       = errors.New("revoked jwt token")
+	ErrInvalidRefreshToken   = errors.New("invalid refresh token")
+	ErrRefreshTokenReused    = errors.New("refresh token reused")
 	ErrNotAllowedImageHeader = errors.New("not allowed image header")
 	ErrNoCookie              = errors.New("not found cookie header")
 	ErrInvalidPhoneNumber    = errors.New("invalid phone number")
+
+	ErrInvalidPersonalAccessToken = errors.New("invalid personal access token")
+	ErrInsufficientTokenScope     = errors.New("insufficient token scope")
+
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication not enabled")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrInvalidLoginChallenge   = errors.New("invalid or expired login challenge")
+
+	ErrInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+
+	ErrEmailNotVerified              = errors.New("email address not verified")
+	ErrEmailAlreadyVerified          = errors.New("email address already verified")
+	ErrInvalidEmailVerificationToken = errors.New("invalid or expired email verification token")
+	ErrEmailVerificationCooldown     = errors.New("verification email sent recently, try again later")
+
+	ErrSessionExpired = errors.New("session expired or revoked")
+
+	ErrTooManyRequests      = errors.New("too many requests")
+	ErrTooManyLoginAttempts = errors.New("too many failed login attempts, try again later")
+
+	ErrWeakPassword = errors.New("password does not meet the password policy")
+
+	ErrBanned = errors.New("your ip address is banned")
 )
 
 type RestErr interface {
@@ -125,6 +161,28 @@ func NewForbiddenError(causes interface{}) RestErr {
 	}
 }
 
+// TooManyRequestsError is a 429 RestError that tells the client when to try again
+type TooManyRequestsError struct {
+	RestError
+	RetryAfter time.Duration `json:"-"`
+}
+
+// RetryAfterSeconds returns the Retry-After header value, rounded up
+func (e TooManyRequestsError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+func NewTooManyRequestsError(err string, retryAfter time.Duration, causes interface{}) RestErr {
+	return TooManyRequestsError{
+		RestError: RestError{
+			ErrStatus: http.StatusTooManyRequests,
+			ErrError:  err,
+			ErrCauses: causes,
+		},
+		RetryAfter: retryAfter,
+	}
+}
+
 func NewInternalServerError(causes interface{}) RestErr {
 	result := RestError{
 		ErrStatus: http.StatusInternalServerError,
@@ -135,14 +193,25 @@ func NewInternalServerError(causes interface{}) RestErr {
 }
 
 func ParseErrors(err error) RestErr {
+	// Errors that already carry their status win over the message matching below
+	var restErr RestErr
+	if errors.As(err, &restErr) {
+		return restErr
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return parseSqlErrors(mysqlErr)
+	}
+
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
 		return NewRestError(http.StatusNotFound, ErrNotFound.Error(), map[string]string{
 			"message": "data not found"})
+	case errors.Is(err, ErrInvalidJWTClaims):
+		// A token of the wrong type, issuer or audience is a client mistake like any other bad token
+		return NewUnauthorizedError(err.Error())
 	case errors.Is(err, context.DeadlineExceeded):
 		return NewRestError(http.StatusRequestTimeout, ErrRequestTimeoutError.Error(), err)
-	case strings.Contains(err.Error(), "SQLSTATE"):
-		return parseSqlErrors(err)
 	case strings.Contains(err.Error(), "Field validation"):
 		return parseValidatorError(err)
 	case strings.Contains(err.Error(), "Unmarshal"):
@@ -160,19 +229,45 @@ func ParseErrors(err error) RestErr {
 			"message": ErrInvalidPhoneNumber.Error(),
 		})
 	default:
-		if restErr, ok := err.(RestErr); ok {
-			return restErr
-		}
 		return NewInternalServerError(err)
 	}
 }
 
-func parseSqlErrors(err error) RestErr {
-	if strings.Contains(err.Error(), "23505") {
-		return NewRestError(http.StatusBadRequest, ErrExistsEmailError.Error(), err)
+// IsDuplicateEntry reports whether err is, or wraps, a MySQL unique key violation
+func IsDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
+
+// parseSqlErrors keeps the driver error out of the response, it names tables and values
+func parseSqlErrors(err *mysql.MySQLError) RestErr {
+	if !IsDuplicateEntry(err) {
+		return NewInternalServerError(nil)
 	}
 
-	return NewRestError(http.StatusBadRequest, ErrBadRequest.Error(), err)
+	switch duplicateKey(err.Message) {
+	case "uq_users_email":
+		return NewRestError(http.StatusConflict, ErrExistsEmailError.Error(), map[string]string{"email": "is already taken"})
+	case "uq_users_username":
+		return NewRestError(http.StatusConflict, ErrExistsUsernameError.Error(), map[string]string{"username": "is already taken"})
+	default:
+		return NewRestError(http.StatusConflict, ErrAlreadyExists.Error(), nil)
+	}
+}
+
+// duplicateKey reads the violated key from a duplicate entry message, the duplicate value comes
+// first so only the part after it is looked at. MySQL 8 prefixes the table: for key 'users.uq_users_email'
+func duplicateKey(message string) string {
+	const marker = "for key '"
+	i := strings.LastIndex(message, marker)
+	if i < 0 {
+		return ""
+	}
+	key := strings.TrimSuffix(message[i+len(marker):], "'")
+	if dot := strings.LastIndex(key, "."); dot >= 0 {
+		key = key[dot+1:]
+	}
+	return key
 }
 
 func parseValidatorError(err error) RestErr {
@@ -184,6 +279,10 @@ func parseValidatorError(err error) RestErr {
 		return NewRestError(http.StatusBadRequest, "Invalid email", err)
 	}
 
+	if strings.Contains(err.Error(), "Username") {
+		return NewRestError(http.StatusBadRequest, "Invalid username, 3 to 20 letters, digits or [ ] ( ) $ @ . _ =", err)
+	}
+
 	return NewRestError(http.StatusBadRequest, ErrBadRequest.Error(), err)
 }
 
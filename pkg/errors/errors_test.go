@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestDuplicateKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"mysql 5.7", "Duplicate entry 'player@example.com' for key 'uq_users_email'", "uq_users_email"},
+		{"mysql 8 table prefix", "Duplicate entry 'player@example.com' for key 'users.uq_users_email'", "uq_users_email"},
+		{"value looks like a key", "Duplicate entry 'x' for key 'uq_users_email'' for key 'users.uq_users_username'", "uq_users_username"},
+		{"value with dots", "Duplicate entry 'a.b.c' for key 'PRIMARY'", "PRIMARY"},
+		{"no key", "Duplicate entry 'a' somewhere", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := duplicateKey(tt.message); got != tt.want {
+				t.Fatalf("duplicateKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrorsMySQL(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:       "email taken",
+			err:        &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'email_tester' for key 'users.uq_users_email'"},
+			wantStatus: http.StatusConflict,
+			wantError:  ErrExistsEmailError.Error(),
+		},
+		{
+			name:       "username taken on mysql 5.7",
+			err:        &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'email_Tester' for key 'uq_users_username'"},
+			wantStatus: http.StatusConflict,
+			wantError:  ErrExistsUsernameError.Error(),
+		},
+		{
+			name:       "other key",
+			err:        &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'PRIMARY'"},
+			wantStatus: http.StatusConflict,
+			wantError:  ErrAlreadyExists.Error(),
+		},
+		{
+			name:       "wrapped",
+			err:        fmt.Errorf("authRepo.Create: %w", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'a' for key 'uq_users_username'"}),
+			wantStatus: http.StatusConflict,
+			wantError:  ErrExistsUsernameError.Error(),
+		},
+		{
+			name:       "other mysql error",
+			err:        &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`evonix`.`roles`)"},
+			wantStatus: http.StatusInternalServerError,
+			wantError:  ErrInternalServerError.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseErrors(tt.err)
+			if got.Status() != tt.wantStatus {
+				t.Fatalf("ParseErrors().Status() = %d, want %d", got.Status(), tt.wantStatus)
+			}
+			if restErr := got.(RestError); restErr.ErrError != tt.wantError {
+				t.Fatalf("ParseErrors().ErrError = %q, want %q", restErr.ErrError, tt.wantError)
+			}
+			if tt.wantStatus == http.StatusInternalServerError && got.Causes() != nil {
+				t.Fatalf("ParseErrors().Causes() = %v, the driver error must not reach the client", got.Causes())
+			}
+		})
+	}
+}
+
+func TestIsDuplicateEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"duplicate entry", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '10.0.0.0/8' for key 'uq_bans_value'"}, true},
+		{"wrapped duplicate entry", fmt.Errorf("bansRepo.Create: %w", &mysql.MySQLError{Number: 1062}), true},
+		{"other mysql error", &mysql.MySQLError{Number: 1452}, false},
+		{"not a mysql error", fmt.Errorf("Duplicate entry '1' for key 'PRIMARY'"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDuplicateEntry(tt.err); got != tt.want {
+				t.Fatalf("IsDuplicateEntry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
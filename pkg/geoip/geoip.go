@@ -0,0 +1,215 @@
+package geoip
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+
+	"github.com/oschwald/geoip2-golang"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultReloadInterval = time.Minute
+	namesLanguage         = "en"
+)
+
+// ASN is the autonomous system an address is announced from
+type ASN struct {
+	Number       uint   `json:"number"`
+	Organization string `json:"organization"`
+}
+
+// City is where an address is located, City is empty when only the country is known
+type City struct {
+	CountryCode string `json:"country_code"`
+	Country     string `json:"country"`
+	City        string `json:"city"`
+}
+
+// Reader looks addresses up in local MaxMind databases, lookups against a database
+// that isn't configured find nothing. Watch reopens a database once its file changes,
+// so geoipupdate can replace the files while the api runs.
+type Reader struct {
+	cfg    config.GeoIP
+	logger logger.Logger
+
+	mu   sync.RWMutex
+	asn  *database
+	city *database
+}
+
+type database struct {
+	path    string
+	reader  *geoip2.Reader
+	modTime time.Time
+	size    int64
+}
+
+// NewReader opens the configured databases
+func NewReader(cfg config.GeoIP, logger logger.Logger) (*Reader, error) {
+	r := &Reader{cfg: cfg, logger: logger}
+
+	var err error
+	if r.asn, err = openDatabase(cfg.ASNDatabase); err != nil {
+		return nil, errors.Wrap(err, "geoip.NewReader.ASN")
+	}
+	if r.city, err = openDatabase(cfg.CityDatabase); err != nil {
+		_ = r.asn.close()
+		return nil, errors.Wrap(err, "geoip.NewReader.City")
+	}
+	return r, nil
+}
+
+// ASN Get the autonomous system of an address, ok is false when it is unknown
+func (r *Reader) ASN(ip net.IP) (asn ASN, ok bool, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.asn == nil || ip == nil {
+		return ASN{}, false, nil
+	}
+
+	record, err := r.asn.reader.ASN(ip)
+	if err != nil {
+		return ASN{}, false, errors.Wrap(err, "geoip.Reader.ASN")
+	}
+	if record.AutonomousSystemNumber == 0 {
+		return ASN{}, false, nil
+	}
+	return ASN{Number: record.AutonomousSystemNumber, Organization: record.AutonomousSystemOrganization}, true, nil
+}
+
+// City Get the country and city of an address, ok is false when the country is unknown
+func (r *Reader) City(ip net.IP) (city City, ok bool, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.city == nil || ip == nil {
+		return City{}, false, nil
+	}
+
+	record, err := r.city.reader.City(ip)
+	if err != nil {
+		return City{}, false, errors.Wrap(err, "geoip.Reader.City")
+	}
+	if record.Country.IsoCode == "" {
+		return City{}, false, nil
+	}
+	return City{
+		CountryCode: record.Country.IsoCode,
+		Country:     record.Country.Names[namesLanguage],
+		City:        record.City.Names[namesLanguage],
+	}, true, nil
+}
+
+// HasASN reports whether an ASN database is loaded
+func (r *Reader) HasASN() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.asn != nil
+}
+
+// Watch reopens the databases whose file changed, every ReloadInterval until ctx is done
+func (r *Reader) Watch(ctx context.Context) {
+	if r.cfg.ASNDatabase == "" && r.cfg.CityDatabase == "" {
+		return
+	}
+
+	interval := r.cfg.ReloadInterval
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reload(&r.asn)
+			r.reload(&r.city)
+		}
+	}
+}
+
+// reload swaps in a changed database, the old one keeps serving when the new one can't be opened
+func (r *Reader) reload(current **database) {
+	r.mu.RLock()
+	db := *current
+	r.mu.RUnlock()
+	if db == nil {
+		return
+	}
+
+	info, err := os.Stat(db.path)
+	if err != nil {
+		r.logger.Errorf("geoip.Reader.reload.Stat, Path: %s, Error: %s", db.path, err)
+		return
+	}
+	if info.ModTime().Equal(db.modTime) && info.Size() == db.size {
+		return
+	}
+
+	reopened, err := openDatabase(db.path)
+	if err != nil {
+		r.logger.Errorf("geoip.Reader.reload.Open, Path: %s, Error: %s", db.path, err)
+		return
+	}
+
+	r.mu.Lock()
+	*current = reopened
+	r.mu.Unlock()
+
+	// Lookups hold the read lock, so nothing uses the old database anymore
+	if err = db.close(); err != nil {
+		r.logger.Errorf("geoip.Reader.reload.Close, Path: %s, Error: %s", db.path, err)
+	}
+	r.logger.Infof("GeoIP database reloaded, Path: %s, BuildTime: %s", db.path, reopened.buildTime())
+}
+
+// Close releases the databases
+func (r *Reader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	asnErr := r.asn.close()
+	if err := r.city.close(); err != nil {
+		return err
+	}
+	return asnErr
+}
+
+func openDatabase(path string) (*database, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &database{path: path, reader: reader, modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+func (d *database) buildTime() string {
+	return time.Unix(int64(d.reader.Metadata().BuildEpoch), 0).UTC().Format(time.RFC3339)
+}
+
+func (d *database) close() error {
+	if d == nil {
+		return nil
+	}
+	return d.reader.Close()
+}
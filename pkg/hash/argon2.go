@@ -0,0 +1,143 @@
+package hash
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Defaults follow the second recommended option of RFC 9106 scaled to 64 MiB
+const (
+	defaultArgon2Memory      = 64 * 1024
+	defaultArgon2Iterations  = 3
+	defaultArgon2Parallelism = 2
+	defaultArgon2SaltLength  = 16
+	defaultArgon2KeyLength   = 32
+)
+
+var errInvalidPHC = errors.New("invalid argon2id phc string")
+
+// Argon2idParams are the cost parameters of argon2id, Memory is in KiB
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// Argon2idHasher hashes passwords with argon2id into PHC strings
+// ($argon2id$v=19$m=65536,t=3,p=2$salt$hash) and verifies bcrypt and legacy hashes as well. The password
+// is peppered with HMAC-SHA256 before hashing so a leaked database alone can't be cracked.
+type Argon2idHasher struct {
+	params Argon2idParams
+	pepper string
+}
+
+// NewArgon2idHasher creates an Argon2idHasher, zero parameters take the defaults
+func NewArgon2idHasher(params Argon2idParams, pepper string) *Argon2idHasher {
+	if params.Memory == 0 {
+		params.Memory = defaultArgon2Memory
+	}
+	if params.Iterations == 0 {
+		params.Iterations = defaultArgon2Iterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = defaultArgon2Parallelism
+	}
+	if params.SaltLength == 0 {
+		params.SaltLength = defaultArgon2SaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = defaultArgon2KeyLength
+	}
+
+	return &Argon2idHasher{params: params, pepper: pepper}
+}
+
+// Hash creates an argon2id PHC string of the password
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey(pepper(password, h.pepper), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+	return encodePHC(h.params, salt, key), nil
+}
+
+func (h *Argon2idHasher) IsEqual(pwdHashed, pwd string) bool {
+	return verify(pwdHashed, pwd, h.pepper)
+}
+
+func (h *Argon2idHasher) NeedsRehash(pwdHashed string) bool {
+	params, salt, _, err := decodePHC(pwdHashed)
+	if err != nil {
+		return true
+	}
+	params.SaltLength = uint32(len(salt))
+	return params != h.params
+}
+
+func verifyArgon2id(pwdHashed, pwd, pepperKey string) bool {
+	params, salt, key, err := decodePHC(pwdHashed)
+	if err != nil {
+		return false
+	}
+
+	other := argon2.IDKey(pepper(pwd, pepperKey), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+// pepper keys the password with the secret pepper, an empty pepper leaves it as is
+func pepper(password, pepperKey string) []byte {
+	if pepperKey == "" {
+		return []byte(password)
+	}
+	mac := hmac.New(sha256.New, []byte(pepperKey))
+	mac.Write([]byte(password))
+	return mac.Sum(nil)
+}
+
+func encodePHC(params Argon2idParams, salt, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func decodePHC(phc string) (Argon2idParams, []byte, []byte, error) {
+	var params Argon2idParams
+
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, hash
+	parts := strings.Split(phc, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return params, nil, nil, errInvalidPHC
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errInvalidPHC
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, errInvalidPHC
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errInvalidPHC
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errInvalidPHC
+	}
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,37 @@
+package hash
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher hashes passwords with bcrypt and verifies argon2id and legacy hashes as well
+type BcryptHasher struct {
+	cost   int
+	pepper string
+}
+
+// NewBcryptHasher creates a BcryptHasher, the pepper is only used to verify argon2id hashes
+func NewBcryptHasher(cost int, pepper string) *BcryptHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptHasher{cost: cost, pepper: pepper}
+}
+
+// Hash creates a bcrypt hash of the password
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashedPassword), nil
+}
+
+func (h *BcryptHasher) IsEqual(pwdHashed, pwd string) bool {
+	return verify(pwdHashed, pwd, h.pepper)
+}
+
+func (h *BcryptHasher) NeedsRehash(pwdHashed string) bool {
+	cost, err := bcrypt.Cost([]byte(pwdHashed))
+	return err != nil || cost != h.cost
+}
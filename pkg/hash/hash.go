@@ -2,42 +2,59 @@ package hash
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Supported algorithms
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
 // PasswordHasher provides hashing logic to securely store passwords.
 type PasswordHasher interface {
 	Hash(password string) (string, error)
 	IsEqual(pwdHashed, pwd string) bool
+	// NeedsRehash reports whether a stored hash uses another algorithm or weaker parameters
+	// than new hashes, so it should be replaced once the password is known
+	NeedsRehash(pwdHashed string) bool
 }
 
-// SHA256Hasher uses SHA1 to hash passwords with provided salt.
-type SHA256Hasher struct {
-	salt string
-}
-
-func NewSHA1Hasher(salt string) *SHA256Hasher {
-	return &SHA256Hasher{salt: salt}
+// NewPasswordHasher creates the PasswordHasher of the configured algorithm
+func NewPasswordHasher(cfg config.PasswordHashing) (PasswordHasher, error) {
+	switch cfg.Algorithm {
+	case AlgorithmArgon2id, "":
+		return NewArgon2idHasher(Argon2idParams{
+			Memory:      cfg.Memory,
+			Iterations:  cfg.Iterations,
+			Parallelism: cfg.Parallelism,
+			SaltLength:  cfg.SaltLength,
+			KeyLength:   cfg.KeyLength,
+		}, cfg.Pepper), nil
+	case AlgorithmBcrypt:
+		return NewBcryptHasher(cfg.BcryptCost, cfg.Pepper), nil
+	default:
+		return nil, fmt.Errorf("unknown password hashing algorithm %q", cfg.Algorithm)
+	}
 }
 
-// Hash creates SHA1 hash of given password.
-func (h *SHA256Hasher) Hash(password string) (string, error) {
-	pwdByte := []byte(password)
-	// Hashing the password with the default cost of 10
-	hashedPassword, err := bcrypt.GenerateFromPassword(pwdByte, 11)
-	if err != nil {
-		return "", err
+// verify checks the password against a hash of any supported format, the pepper applies to argon2id only
+func verify(pwdHashed, pwd, pepper string) bool {
+	switch {
+	case isBcrypt(pwdHashed):
+		return bcrypt.CompareHashAndPassword([]byte(pwdHashed), []byte(pwd)) == nil
+	case isLegacy(pwdHashed):
+		return verifyLegacy(pwdHashed, pwd)
+	default:
+		return verifyArgon2id(pwdHashed, pwd, pepper)
 	}
-	return string(hashedPassword), nil
 }
 
-func (h *SHA256Hasher) IsEqual(pwdHashed, pwd string) bool {
-	// Comparing the password with the hash
-	err := bcrypt.CompareHashAndPassword([]byte(pwdHashed), []byte(pwd))
-	if err != nil {
-		fmt.Println(err) // nil means it is a match
-		return false
-	}
-	return true
+// isBcrypt tells bcrypt hashes ($2a$, $2b$, $2y$) apart from PHC strings of other algorithms
+func isBcrypt(pwdHashed string) bool {
+	return strings.HasPrefix(pwdHashed, "$2")
 }
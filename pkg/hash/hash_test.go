@@ -0,0 +1,110 @@
+package hash
+
+import "testing"
+
+// Cheap parameters keep the tests fast, the format is the same as in production
+var testParams = Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestHashersVerifyEveryFormat(t *testing.T) {
+	argon := NewArgon2idHasher(testParams, "pepper")
+	bcryptHasher := NewBcryptHasher(4, "pepper")
+
+	argonHash, err := argon.Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcryptHash, err := bcryptHasher.Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacyHash := EncodeLegacy(AlgorithmLegacySHA256, "s4lt", sha256Hex("secret"+"s4lt"))
+
+	for _, hasher := range []PasswordHasher{argon, bcryptHasher} {
+		for _, hashed := range []string{argonHash, bcryptHash, legacyHash} {
+			if !hasher.IsEqual(hashed, "secret") {
+				t.Errorf("%T.IsEqual(%q) rejected the right password", hasher, hashed)
+			}
+			if hasher.IsEqual(hashed, "wrong") {
+				t.Errorf("%T.IsEqual(%q) accepted a wrong password", hasher, hashed)
+			}
+		}
+	}
+
+	// The pepper is part of argon2id hashes, another pepper must not verify them
+	if NewArgon2idHasher(testParams, "other").IsEqual(argonHash, "secret") {
+		t.Error("argon2id hash verified with another pepper")
+	}
+}
+
+func TestArgon2idNeedsRehash(t *testing.T) {
+	hasher := NewArgon2idHasher(testParams, "")
+	current, err := hasher.Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weaker := testParams
+	weaker.Iterations = testParams.Iterations + 1
+	other, err := NewArgon2idHasher(weaker, "").Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcryptHash, err := NewBcryptHasher(4, "").Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		hashed string
+		want   bool
+	}{
+		{"current parameters", current, false},
+		{"other parameters", other, true},
+		{"bcrypt", bcryptHash, true},
+		{"legacy", sha256Hex("secret"), true},
+		{"garbage", "not a hash", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasher.NeedsRehash(tt.hashed); got != tt.want {
+				t.Fatalf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBcryptNeedsRehash(t *testing.T) {
+	hasher := NewBcryptHasher(5, "")
+	current, err := hasher.Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cheaper, err := NewBcryptHasher(4, "").Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	argonHash, err := NewArgon2idHasher(testParams, "").Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		hashed string
+		want   bool
+	}{
+		{"current cost", current, false},
+		{"other cost", cheaper, true},
+		{"argon2id", argonHash, true},
+		{"legacy", sha256Hex("secret"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasher.NeedsRehash(tt.hashed); got != tt.want {
+				t.Fatalf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package hash
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	stdhash "hash"
+	"strings"
+
+	"github.com/jzelinskie/whirlpool"
+)
+
+// Legacy algorithms of the gamemode and the v1 UCP, stored as hex digests of password + salt
+const (
+	AlgorithmLegacySHA256    = "sha256"
+	AlgorithmLegacyWhirlpool = "whirlpool"
+
+	whirlpoolSize = 64
+)
+
+// EncodeLegacy turns a legacy hex digest and its salt from the users row into a PHC-like
+// string ($sha256$<base64 salt>$<hex digest>) the hashers can verify. An empty algorithm
+// is detected from the digest length, 64 hex chars for SHA-256 and 128 for Whirlpool.
+func EncodeLegacy(algorithm, salt, digest string) string {
+	if algorithm == "" {
+		algorithm = detectLegacy(digest)
+	}
+	return "$" + strings.ToLower(algorithm) + "$" + base64.RawStdEncoding.EncodeToString([]byte(salt)) + "$" + digest
+}
+
+// isLegacy tells legacy hashes apart, either encoded by EncodeLegacy or bare unsalted digests
+func isLegacy(pwdHashed string) bool {
+	if detectLegacy(pwdHashed) != "" {
+		return true
+	}
+	return strings.HasPrefix(pwdHashed, "$"+AlgorithmLegacySHA256+"$") ||
+		strings.HasPrefix(pwdHashed, "$"+AlgorithmLegacyWhirlpool+"$")
+}
+
+func verifyLegacy(pwdHashed, pwd string) bool {
+	algorithm, salt, digest := detectLegacy(pwdHashed), "", pwdHashed
+	if algorithm == "" {
+		// "", algorithm, salt, digest
+		parts := strings.Split(pwdHashed, "$")
+		if len(parts) != 4 || parts[0] != "" {
+			return false
+		}
+		decoded, err := base64.RawStdEncoding.DecodeString(parts[2])
+		if err != nil {
+			return false
+		}
+		algorithm, salt, digest = parts[1], string(decoded), parts[3]
+	}
+
+	var h stdhash.Hash
+	switch algorithm {
+	case AlgorithmLegacySHA256:
+		h = sha256.New()
+	case AlgorithmLegacyWhirlpool:
+		h = whirlpool.New()
+	default:
+		return false
+	}
+
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+	h.Write([]byte(pwd + salt))
+	return subtle.ConstantTimeCompare(expected, h.Sum(nil)) == 1
+}
+
+func detectLegacy(digest string) string {
+	if _, err := hex.DecodeString(digest); err != nil {
+		return ""
+	}
+	switch len(digest) {
+	case sha256.Size * 2:
+		return AlgorithmLegacySHA256
+	case whirlpoolSize * 2:
+		return AlgorithmLegacyWhirlpool
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,51 @@
+package hash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/jzelinskie/whirlpool"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func whirlpoolHex(s string) string {
+	h := whirlpool.New()
+	h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func TestVerifyLegacy(t *testing.T) {
+	tests := []struct {
+		name   string
+		hashed string
+		pwd    string
+		want   bool
+	}{
+		{"salted sha256", EncodeLegacy(AlgorithmLegacySHA256, "s4lt", sha256Hex("secret"+"s4lt")), "secret", true},
+		{"salted sha256 wrong password", EncodeLegacy(AlgorithmLegacySHA256, "s4lt", sha256Hex("secret"+"s4lt")), "Secret", false},
+		{"salted sha256 wrong salt", EncodeLegacy(AlgorithmLegacySHA256, "other", sha256Hex("secret"+"s4lt")), "secret", false},
+		{"salted whirlpool", EncodeLegacy(AlgorithmLegacyWhirlpool, "s4lt", whirlpoolHex("secret"+"s4lt")), "secret", true},
+		{"whirlpool detected from length", EncodeLegacy("", "s4lt", whirlpoolHex("secret"+"s4lt")), "secret", true},
+		{"uppercase algorithm", EncodeLegacy("SHA256", "", sha256Hex("secret")), "secret", true},
+		{"bare sha256", sha256Hex("secret"), "secret", true},
+		{"bare whirlpool", whirlpoolHex("secret"), "secret", true},
+		{"bare sha256 wrong password", sha256Hex("secret"), "secret2", false},
+		{"unknown algorithm", "$md5$c2FsdA$" + sha256Hex("secret"), "secret", false},
+		{"invalid salt", "$sha256$!!$" + sha256Hex("secret"), "secret", false},
+		{"invalid digest", "$sha256$c2FsdA$zz", "secret", false},
+		{"missing parts", "$sha256$" + sha256Hex("secret"), "secret", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyLegacy(tt.hashed, tt.pwd); got != tt.want {
+				t.Fatalf("verifyLegacy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package iptree
+
+import "net"
+
+// Tree is a binary trie of IPv4 and IPv6 networks with one level per prefix bit, paths are not
+// compressed so a lookup walks at most 32 or 128 nodes. Every network can hold several values
+// and a lookup returns those of every network containing the address, most specific first.
+// A Tree is not safe for concurrent writes, build it once and swap it in whole.
+type Tree struct {
+	v4 *node
+	v6 *node
+}
+
+type node struct {
+	children [2]*node
+	values   []interface{}
+}
+
+// New creates an empty Tree
+func New() *Tree {
+	return &Tree{v4: &node{}, v6: &node{}}
+}
+
+// Insert adds a value to a network
+func (t *Tree) Insert(network *net.IPNet, value interface{}) {
+	ip, root := t.root(network.IP)
+	if ip == nil {
+		return
+	}
+	ones, bits := network.Mask.Size()
+	if bits != len(ip)*8 {
+		return
+	}
+
+	n := root
+	for i := 0; i < ones; i++ {
+		bit := bitAt(ip, i)
+		if n.children[bit] == nil {
+			n.children[bit] = &node{}
+		}
+		n = n.children[bit]
+	}
+	n.values = append(n.values, value)
+}
+
+// Lookup returns the values of every network containing the address, most specific first
+func (t *Tree) Lookup(ip net.IP) []interface{} {
+	ip, n := t.root(ip)
+	if ip == nil {
+		return nil
+	}
+
+	var matches [][]interface{}
+	for i := 0; n != nil; i++ {
+		if len(n.values) > 0 {
+			matches = append(matches, n.values)
+		}
+		if i == len(ip)*8 {
+			break
+		}
+		n = n.children[bitAt(ip, i)]
+	}
+
+	var values []interface{}
+	for i := len(matches) - 1; i >= 0; i-- {
+		values = append(values, matches[i]...)
+	}
+	return values
+}
+
+func (t *Tree) root(ip net.IP) (net.IP, *node) {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4, t.v4
+	}
+	if ip6 := ip.To16(); ip6 != nil {
+		return ip6, t.v6
+	}
+	return nil, nil
+}
+
+func bitAt(ip net.IP, i int) int {
+	return int(ip[i/8]>>(7-uint(i%8))) & 1
+}
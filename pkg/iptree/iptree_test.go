@@ -0,0 +1,85 @@
+package iptree
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func mustCIDR(t *testing.T, value string) *net.IPNet {
+	t.Helper()
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return network
+}
+
+func TestLookup(t *testing.T) {
+	tree := New()
+	for _, network := range []string{
+		"0.0.0.0/1",
+		"10.0.0.0/8",
+		"10.1.0.0/16",
+		"10.1.2.3/32",
+		"192.168.0.0/24",
+		"2001:db8::/32",
+		"2001:db8:1::/48",
+		"2001:db8:1::1/128",
+	} {
+		tree.Insert(mustCIDR(t, network), network)
+	}
+	tree.Insert(mustCIDR(t, "10.1.0.0/16"), "second value of 10.1.0.0/16")
+
+	tests := []struct {
+		ip   string
+		want []interface{}
+	}{
+		{"10.1.2.3", []interface{}{"10.1.2.3/32", "10.1.0.0/16", "second value of 10.1.0.0/16", "10.0.0.0/8", "0.0.0.0/1"}},
+		{"10.1.2.4", []interface{}{"10.1.0.0/16", "second value of 10.1.0.0/16", "10.0.0.0/8", "0.0.0.0/1"}},
+		{"10.2.0.1", []interface{}{"10.0.0.0/8", "0.0.0.0/1"}},
+		{"127.0.0.1", []interface{}{"0.0.0.0/1"}},
+		{"192.168.0.255", []interface{}{"192.168.0.0/24"}},
+		{"192.168.1.0", nil},
+		{"200.0.0.1", nil},
+		// IPv4-mapped addresses are looked up as IPv4
+		{"::ffff:10.1.2.3", []interface{}{"10.1.2.3/32", "10.1.0.0/16", "second value of 10.1.0.0/16", "10.0.0.0/8", "0.0.0.0/1"}},
+		{"2001:db8:1::1", []interface{}{"2001:db8:1::1/128", "2001:db8:1::/48", "2001:db8::/32"}},
+		{"2001:db8:2::1", []interface{}{"2001:db8::/32"}},
+		{"2001:db9::1", nil},
+		// IPv4 networks never match IPv6 addresses and the other way round
+		{"::a01:203", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			got := tree.Lookup(net.ParseIP(tt.ip))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Lookup(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupEmptyAndInvalid(t *testing.T) {
+	tree := New()
+	if got := tree.Lookup(net.ParseIP("10.0.0.1")); got != nil {
+		t.Fatalf("empty tree Lookup = %v", got)
+	}
+	tree.Insert(mustCIDR(t, "0.0.0.0/0"), "everything")
+	if got := tree.Lookup(nil); got != nil {
+		t.Fatalf("Lookup(nil) = %v", got)
+	}
+	if got := tree.Lookup(net.ParseIP("1.2.3.4")); !reflect.DeepEqual(got, []interface{}{"everything"}) {
+		t.Fatalf("Lookup under /0 = %v", got)
+	}
+}
+
+func TestInsertIgnoresMismatchedMask(t *testing.T) {
+	tree := New()
+	// An IPv4 address with an IPv6 sized mask can't be placed in either tree
+	tree.Insert(&net.IPNet{IP: net.ParseIP("10.0.0.0").To4(), Mask: net.CIDRMask(8, 128)}, "broken")
+	if got := tree.Lookup(net.ParseIP("10.0.0.1")); got != nil {
+		t.Fatalf("Lookup = %v, want nothing", got)
+	}
+}
@@ -0,0 +1,51 @@
+package jwt
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+func init() {
+	// Whole second iat can't tell a token issued right after RevokeUser from the ones it revoked.
+	// Microseconds rather than milliseconds, parsing the numeric date as a float may lose the last digit.
+	jwt.TimePrecision = time.Microsecond
+}
+
+// TokenType tells access tokens and refresh tokens apart, each one is only accepted where it belongs
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"
+	TokenTypeRefresh TokenType = "refresh"
+)
+
+// Identity is what a token says about its user
+type Identity struct {
+	UserID        string
+	AdminLevel    int
+	Roles         []string
+	EmailVerified bool
+}
+
+// Claims are the claims carried by every token the Manager issues. The user UUID is the
+// subject, the session ID identifies the login (the refresh token family) the token belongs to.
+type Claims struct {
+	jwt.RegisteredClaims
+	AdminLevel    int       `json:"adm,omitempty"`
+	Roles         []string  `json:"roles,omitempty"`
+	EmailVerified bool      `json:"email_verified,omitempty"`
+	SessionID     string    `json:"sid"`
+	TokenType     TokenType `json:"typ"`
+}
+
+// UserUUID parses the subject as the user UUID
+func (c *Claims) UserUUID() (uuid.UUID, error) {
+	return uuid.Parse(c.Subject)
+}
+
+// Identity returns the identity the token was issued for
+func (c *Claims) Identity() Identity {
+	return Identity{UserID: c.Subject, AdminLevel: c.AdminLevel, Roles: c.Roles, EmailVerified: c.EmailVerified}
+}
@@ -1,65 +1,291 @@
 package jwt
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/iamaul/go-evonix-backend-api/config"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAccessTokenTTL  = time.Minute * 15
+	defaultRefreshTokenTTL = time.Hour * 24 * 30
 )
 
+// Tokens is an access/refresh token pair
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
 type TokenManager interface {
-	NewJWT(userId string) (string, error)
-	Parse(accessToken string) (string, error)
-	NewRefreshToken(userId string) (string, error)
+	// NewTokens starts a new session (refresh token family) for the identity, typically on login
+	NewTokens(ctx context.Context, identity Identity) (Tokens, error)
+	// Parse validates signature and claims of an access token, refresh tokens are rejected
+	Parse(accessToken string) (*Claims, error)
+	// Verify parses the access token and rejects it when it has been revoked
+	Verify(ctx context.Context, accessToken string) (*Claims, error)
+	// Refresh rotates the refresh token and issues a new pair, presenting an already
+	// rotated token revokes its whole family
+	Refresh(ctx context.Context, refreshToken string) (Tokens, error)
+	// RevokeRefreshToken revokes the family of the given refresh token, typically on logout
+	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	// RevokeFamily revokes the refresh token family of a login, its access tokens stay valid until they expire
+	RevokeFamily(ctx context.Context, sessionID string) error
+	// RevokeSession revokes an access token and the refresh token family of its login
+	RevokeSession(ctx context.Context, sessionID, tokenID string, expiresAt time.Time) error
+	// RevokeUser revokes every token issued to the user so far, on every device
+	RevokeUser(ctx context.Context, userID string) error
+	// JWKS returns the public verification keys
+	JWKS() JWKS
+}
+
+// IdentityLoader reloads the identity of a user when a refresh token is rotated, so changes
+// such as a new admin level or a verified email reach the next access token
+type IdentityLoader interface {
+	LoadIdentity(ctx context.Context, userID string) (Identity, error)
 }
 
 type Manager struct {
-	signingKey string
+	keys            *KeySet
+	issuer          string
+	audience        []string
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	refreshStore    RefreshStore
+	revocationStore RevocationStore
+	identityLoader  IdentityLoader
 }
 
-func NewManager(signingKey string) (*Manager, error) {
-	if signingKey == "" {
-		return nil, errors.New("empty signing key")
+// NewManager creates a Manager, without an identity loader refreshed tokens keep the identity of the old ones
+func NewManager(
+	keys *KeySet,
+	cfg config.Jwt,
+	refreshStore RefreshStore,
+	revocationStore RevocationStore,
+	identityLoader IdentityLoader,
+) (*Manager, error) {
+	if keys == nil {
+		return nil, errors.New("empty key set")
+	}
+	if refreshStore == nil {
+		return nil, errors.New("empty refresh store")
+	}
+	if revocationStore == nil {
+		return nil, errors.New("empty revocation store")
+	}
+	if cfg.Issuer == "" {
+		return nil, errors.New("empty issuer")
+	}
+
+	m := &Manager{
+		keys:            keys,
+		issuer:          cfg.Issuer,
+		audience:        cfg.Audience,
+		accessTokenTTL:  cfg.AccessTokenExpire,
+		refreshTokenTTL: cfg.RefreshTokenExpire,
+		refreshStore:    refreshStore,
+		revocationStore: revocationStore,
+		identityLoader:  identityLoader,
+	}
+	if m.accessTokenTTL <= 0 {
+		m.accessTokenTTL = defaultAccessTokenTTL
+	}
+	if m.refreshTokenTTL <= 0 {
+		m.refreshTokenTTL = defaultRefreshTokenTTL
 	}
 
-	return &Manager{signingKey: signingKey}, nil
+	return m, nil
 }
 
-func (m *Manager) NewJWT(userId string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
-		ExpiresAt: time.Now().Add(time.Hour * 5).Unix(),
-		Subject:   userId,
-	})
+func (m *Manager) NewTokens(ctx context.Context, identity Identity) (Tokens, error) {
+	return m.issueTokens(ctx, identity, uuid.NewString(), true)
+}
 
-	return token.SignedString([]byte(m.signingKey))
+func (m *Manager) Parse(accessToken string) (*Claims, error) {
+	return m.parse(accessToken, TokenTypeAccess)
 }
 
-func (m *Manager) Parse(accessToken string) (string, error) {
-	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (i interface{}, err error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+func (m *Manager) Verify(ctx context.Context, accessToken string) (*Claims, error) {
+	claims, err := m.parse(accessToken, TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := m.revocationStore.IsRevoked(ctx, claims.ID, claims.Subject, claims.IssuedAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, httpErr.ErrRevokedJWTToken
+	}
+
+	return claims, nil
+}
+
+func (m *Manager) Refresh(ctx context.Context, refreshToken string) (Tokens, error) {
+	claims, err := m.parse(refreshToken, TokenTypeRefresh)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	revoked, err := m.revocationStore.IsRevoked(ctx, claims.ID, claims.Subject, claims.IssuedAt.Time)
+	if err != nil {
+		return Tokens{}, err
+	}
+	if revoked {
+		return Tokens{}, httpErr.ErrInvalidRefreshToken
+	}
+
+	stored, err := m.refreshStore.Get(ctx, claims.ID)
+	if err != nil {
+		return Tokens{}, err
+	}
+	if stored == nil {
+		return Tokens{}, httpErr.ErrInvalidRefreshToken
+	}
+
+	active, err := m.refreshStore.IsFamilyActive(ctx, stored.FamilyID)
+	if err != nil {
+		return Tokens{}, err
+	}
+	if !active {
+		return Tokens{}, httpErr.ErrInvalidRefreshToken
+	}
+
+	first, err := m.refreshStore.MarkUsed(ctx, claims.ID)
+	if err != nil {
+		return Tokens{}, err
+	}
+	if stored.Used || !first {
+		// The token has already been rotated, so either the legitimate client or an attacker
+		// holds a stolen copy. We can't tell which one, so the whole family goes.
+		if err = m.refreshStore.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return Tokens{}, err
+		}
+		return Tokens{}, httpErr.ErrRefreshTokenReused
+	}
+
+	identity := claims.Identity()
+	if m.identityLoader != nil {
+		if identity, err = m.identityLoader.LoadIdentity(ctx, claims.Subject); err != nil {
+			return Tokens{}, err
 		}
+	}
+
+	return m.issueTokens(ctx, identity, stored.FamilyID, false)
+}
+
+func (m *Manager) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	claims, err := m.parse(refreshToken, TokenTypeRefresh)
+	if err != nil {
+		return err
+	}
+
+	return m.refreshStore.RevokeFamily(ctx, claims.SessionID)
+}
+
+func (m *Manager) RevokeFamily(ctx context.Context, sessionID string) error {
+	return m.refreshStore.RevokeFamily(ctx, sessionID)
+}
+
+func (m *Manager) RevokeSession(ctx context.Context, sessionID, tokenID string, expiresAt time.Time) error {
+	if err := m.revocationStore.RevokeToken(ctx, tokenID, expiresAt); err != nil {
+		return err
+	}
+	return m.refreshStore.RevokeFamily(ctx, sessionID)
+}
+
+func (m *Manager) RevokeUser(ctx context.Context, userID string) error {
+	// Refresh tokens live longest, once they expire the cutoff is meaningless
+	return m.revocationStore.RevokeUser(ctx, userID, time.Now(), m.refreshTokenTTL)
+}
+
+func (m *Manager) JWKS() JWKS {
+	return m.keys.JWKS()
+}
 
-		return []byte(m.signingKey), nil
-	})
+func (m *Manager) issueTokens(ctx context.Context, identity Identity, sessionID string, newFamily bool) (Tokens, error) {
+	now := time.Now()
+
+	accessToken, err := m.keys.Sign(m.newClaims(identity, sessionID, TokenTypeAccess, now, m.accessTokenTTL))
 	if err != nil {
-		return "", err
+		return Tokens{}, err
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return "", fmt.Errorf("error get user claims from token")
+	refreshClaims := m.newClaims(identity, sessionID, TokenTypeRefresh, now, m.refreshTokenTTL)
+	refreshToken, err := m.keys.Sign(refreshClaims)
+	if err != nil {
+		return Tokens{}, err
+	}
+	if err = m.refreshStore.Save(ctx, refreshClaims.ID, RefreshToken{
+		UserID:   identity.UserID,
+		FamilyID: sessionID,
+	}, m.refreshTokenTTL, newFamily); err != nil {
+		return Tokens{}, err
 	}
 
-	return claims["sub"].(string), nil
+	return Tokens{AccessToken: accessToken, RefreshToken: refreshToken}, nil
 }
 
-func (m *Manager) NewRefreshToken(userId string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
-		ExpiresAt: time.Now().AddDate(1, 0, 0).Unix(),
-		Subject:   userId,
-	})
+func (m *Manager) newClaims(identity Identity, sessionID string, tokenType TokenType, now time.Time, ttl time.Duration) *Claims {
+	return &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    m.issuer,
+			Audience:  m.audience,
+			Subject:   identity.UserID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		AdminLevel:    identity.AdminLevel,
+		Roles:         identity.Roles,
+		EmailVerified: identity.EmailVerified,
+		SessionID:     sessionID,
+		TokenType:     tokenType,
+	}
+}
 
-	return token.SignedString([]byte(m.signingKey))
+func (m *Manager) parse(tokenString string, tokenType TokenType) (*Claims, error) {
+	claims := &Claims{}
+	if _, err := jwt.ParseWithClaims(tokenString, claims, m.keys.Keyfunc); err != nil {
+		var validationErr *jwt.ValidationError
+		if errors.As(err, &validationErr) {
+			switch {
+			case validationErr.Errors&jwt.ValidationErrorExpired != 0:
+				return nil, httpErr.ErrExpiredJWTToken
+			case validationErr.Errors&(jwt.ValidationErrorIssuedAt|jwt.ValidationErrorNotValidYet|jwt.ValidationErrorClaimsInvalid) != 0:
+				return nil, httpErr.ErrInvalidJWTClaims
+			}
+		}
+		return nil, httpErr.ErrInvalidJWTToken
+	}
+
+	if claims.TokenType != tokenType || claims.Subject == "" || claims.ID == "" || claims.SessionID == "" ||
+		claims.IssuedAt == nil || claims.ExpiresAt == nil {
+		return nil, httpErr.ErrInvalidJWTClaims
+	}
+	if !claims.VerifyIssuer(m.issuer, true) {
+		return nil, httpErr.ErrInvalidJWTClaims
+	}
+	if len(m.audience) > 0 && !m.verifyAudience(claims) {
+		return nil, httpErr.ErrInvalidJWTClaims
+	}
+
+	return claims, nil
+}
+
+func (m *Manager) verifyAudience(claims *Claims) bool {
+	for _, aud := range m.audience {
+		if claims.VerifyAudience(aud, true) {
+			return true
+		}
+	}
+	return false
 }
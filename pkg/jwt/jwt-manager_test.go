@@ -0,0 +1,72 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+)
+
+func TestTokenClaimsRejected(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	m := newTestManager(t, client)
+
+	tokens, err := m.NewTokens(ctx, Identity{UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Same keys and stores, only the issuer or the audience differs
+	other := *m
+	other.issuer = "other"
+	foreignIssuer, err := other.NewTokens(ctx, Identity{UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other = *m
+	other.audience = []string{"evonix-admin"}
+	foreignAudience, err := other.NewTokens(ctx, Identity{UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"access token sent to Refresh", func() error {
+			_, err := m.Refresh(ctx, tokens.AccessToken)
+			return err
+		}},
+		{"refresh token sent to Verify", func() error {
+			_, err := m.Verify(ctx, tokens.RefreshToken)
+			return err
+		}},
+		{"access token sent to RevokeRefreshToken", func() error {
+			return m.RevokeRefreshToken(ctx, tokens.AccessToken)
+		}},
+		{"other issuer", func() error {
+			_, err := m.Verify(ctx, foreignIssuer.AccessToken)
+			return err
+		}},
+		{"other audience", func() error {
+			_, err := m.Refresh(ctx, foreignAudience.RefreshToken)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if !errors.Is(err, httpErr.ErrInvalidJWTClaims) {
+				t.Fatalf("error = %v, want %v", err, httpErr.ErrInvalidJWTClaims)
+			}
+			if status := httpErr.ParseErrors(err).Status(); status != http.StatusUnauthorized {
+				t.Fatalf("ParseErrors().Status() = %d, want %d", status, http.StatusUnauthorized)
+			}
+		})
+	}
+}
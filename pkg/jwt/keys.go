@@ -0,0 +1,237 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"sort"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	// defaultHMACKeyID is used for the shared secret when no asymmetric keys are configured
+	defaultHMACKeyID = "default"
+)
+
+// Key is a single signing or verification key identified by its kid
+type Key struct {
+	ID        string
+	Method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// CanSign reports whether the private part of the key is available
+func (k *Key) CanSign() bool {
+	return k.signKey != nil
+}
+
+// KeySet holds the key used for signing new tokens and every key still accepted for
+// verification, which lets old keys keep verifying tokens while a new key takes over
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+}
+
+// NewKeySet creates a key set signing with signingKeyID, every key is accepted for verification
+func NewKeySet(signingKeyID string, keys ...*Key) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*Key, len(keys))}
+	for _, k := range keys {
+		if _, exists := ks.keys[k.ID]; exists {
+			return nil, fmt.Errorf("duplicate jwt key id: %s", k.ID)
+		}
+		ks.keys[k.ID] = k
+	}
+
+	signing, ok := ks.keys[signingKeyID]
+	if !ok {
+		return nil, fmt.Errorf("jwt signing key not found: %s", signingKeyID)
+	}
+	if !signing.CanSign() {
+		return nil, fmt.Errorf("jwt signing key has no private key: %s", signingKeyID)
+	}
+	ks.signing = signing
+
+	return ks, nil
+}
+
+// NewHMACKey creates a symmetric HS256 key
+func NewHMACKey(id, secret string) (*Key, error) {
+	if secret == "" {
+		return nil, errors.New("empty signing key")
+	}
+	return &Key{ID: id, Method: jwt.SigningMethodHS256, signKey: []byte(secret), verifyKey: []byte(secret)}, nil
+}
+
+// LoadKeySet builds the key set from config. Without configured keys it falls back to
+// HS256 with the shared Server.JwtSecretKey.
+func LoadKeySet(cfg *config.Config) (*KeySet, error) {
+	if len(cfg.Jwt.Keys) == 0 {
+		key, err := NewHMACKey(defaultHMACKeyID, cfg.Server.JwtSecretKey)
+		if err != nil {
+			return nil, err
+		}
+		return NewKeySet(defaultHMACKeyID, key)
+	}
+
+	keys := make([]*Key, 0, len(cfg.Jwt.Keys))
+	for _, keyCfg := range cfg.Jwt.Keys {
+		key, err := loadKey(keyCfg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "jwt key %s", keyCfg.ID)
+		}
+		keys = append(keys, key)
+	}
+
+	return NewKeySet(cfg.Jwt.SigningKeyID, keys...)
+}
+
+func loadKey(keyCfg config.JwtKey) (*Key, error) {
+	if keyCfg.ID == "" {
+		return nil, errors.New("empty key id")
+	}
+
+	var (
+		privatePEM, publicPEM []byte
+		err                   error
+	)
+	if keyCfg.PrivateKeyFile != "" {
+		if privatePEM, err = ioutil.ReadFile(keyCfg.PrivateKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	if keyCfg.PublicKeyFile != "" {
+		if publicPEM, err = ioutil.ReadFile(keyCfg.PublicKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	if privatePEM == nil && publicPEM == nil {
+		return nil, errors.New("neither private nor public key file configured")
+	}
+
+	key := &Key{ID: keyCfg.ID}
+	switch keyCfg.Algorithm {
+	case AlgorithmRS256:
+		key.Method = jwt.SigningMethodRS256
+		if privatePEM != nil {
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+			if err != nil {
+				return nil, err
+			}
+			key.signKey = privateKey
+			key.verifyKey = &privateKey.PublicKey
+		}
+		if publicPEM != nil {
+			if key.verifyKey, err = jwt.ParseRSAPublicKeyFromPEM(publicPEM); err != nil {
+				return nil, err
+			}
+		}
+	case AlgorithmEdDSA:
+		key.Method = jwt.SigningMethodEdDSA
+		if privatePEM != nil {
+			privateKey, err := jwt.ParseEdPrivateKeyFromPEM(privatePEM)
+			if err != nil {
+				return nil, err
+			}
+			key.signKey = privateKey
+			key.verifyKey = privateKey.(crypto.Signer).Public()
+		}
+		if publicPEM != nil {
+			if key.verifyKey, err = jwt.ParseEdPublicKeyFromPEM(publicPEM); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %s", keyCfg.Algorithm)
+	}
+
+	return key, nil
+}
+
+// Sign signs the claims with the current signing key and sets the kid header
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signing.Method, claims)
+	token.Header["kid"] = ks.signing.ID
+	return token.SignedString(ks.signing.signKey)
+}
+
+// Keyfunc resolves the verification key from the kid header. Tokens signed before kid
+// headers existed carry none and are only accepted by the default HMAC key.
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		kid = defaultHMACKeyID
+	}
+
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.verifyKey, nil
+}
+
+// JWK is a public key in RFC 7517 format
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public part of every asymmetric key, symmetric keys are never published
+func (ks *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: make([]JWK, 0, len(ks.keys))}
+	for _, key := range ks.keys {
+		switch pub := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				KeyType:   "RSA",
+				KeyID:     key.ID,
+				Use:       "sig",
+				Algorithm: key.Method.Alg(),
+				N:         base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				KeyType:   "OKP",
+				KeyID:     key.ID,
+				Use:       "sig",
+				Algorithm: key.Method.Alg(),
+				Curve:     "Ed25519",
+				X:         base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+
+	// Deterministic output keeps the response cacheable
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].KeyID < set.Keys[j].KeyID
+	})
+	return set
+}
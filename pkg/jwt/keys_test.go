@@ -0,0 +1,89 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func newTestKeySet(t *testing.T) (*KeySet, *rsa.PrivateKey) {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hmacKey, err := NewHMACKey(defaultHMACKeyID, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ks, err := NewKeySet("rsa",
+		&Key{ID: "rsa", Method: jwt.SigningMethodRS256, signKey: rsaKey, verifyKey: &rsaKey.PublicKey},
+		&Key{ID: "ed", Method: jwt.SigningMethodEdDSA, signKey: edPrivate, verifyKey: edPublic},
+		hmacKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ks, rsaKey
+}
+
+// signWith signs like an attacker or an old deployment would, with any method, kid and key
+func signWith(t *testing.T, method jwt.SigningMethod, kid string, key interface{}) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, jwt.RegisteredClaims{Subject: "user"})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestKeyfunc(t *testing.T) {
+	ks, rsaKey := newTestKeySet(t)
+
+	signed, err := ks.Sign(jwt.RegisteredClaims{Subject: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"current signing key", signed, false},
+		{"other accepted ed25519 key", signWith(t, jwt.SigningMethodEdDSA, "ed", ks.keys["ed"].signKey), false},
+		{"hmac without kid", signWith(t, jwt.SigningMethodHS256, "", []byte("secret")), false},
+		{"unknown kid", signWith(t, jwt.SigningMethodHS256, "retired", []byte("secret")), true},
+		{"hmac signed with the rsa public key", signWith(t, jwt.SigningMethodHS256, "rsa", publicDER), true},
+		{"rsa token with the hmac kid", signWith(t, jwt.SigningMethodRS256, defaultHMACKeyID, rsaKey), true},
+		{"no kid is not an asymmetric token", signWith(t, jwt.SigningMethodRS256, "", rsaKey), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwt.Parse(tt.token, ks.Keyfunc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
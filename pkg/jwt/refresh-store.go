@@ -0,0 +1,136 @@
+package jwt
+
+import (
+	"context"
+	"time"
+
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+)
+
+// RefreshToken is the server side record of an issued refresh token, keyed by its jti
+type RefreshToken struct {
+	UserID   string
+	FamilyID string
+	Used     bool
+}
+
+// RefreshStore persists refresh tokens grouped in families, a family starts at login
+// and every rotation adds a new token to it
+type RefreshStore interface {
+	// Save creates the family of a new login, a rotation only extends a family that still exists
+	// and fails with ErrInvalidRefreshToken once it has been revoked
+	Save(ctx context.Context, tokenID string, token RefreshToken, expire time.Duration, newFamily bool) error
+	Get(ctx context.Context, tokenID string) (*RefreshToken, error)
+	// MarkUsed flags the token as consumed and reports whether this call was the first to do so
+	MarkUsed(ctx context.Context, tokenID string) (bool, error)
+	IsFamilyActive(ctx context.Context, familyID string) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+}
+
+type redisRefreshStore struct {
+	redisClient *redis.Client
+	prefix      string
+}
+
+// NewRedisRefreshStore creates a RefreshStore backed by redis, keys are namespaced with prefix
+func NewRedisRefreshStore(redisClient *redis.Client, prefix string) *redisRefreshStore {
+	return &redisRefreshStore{redisClient: redisClient, prefix: prefix}
+}
+
+const (
+	refreshFieldUserID   = "user_id"
+	refreshFieldFamilyID = "family_id"
+	refreshFieldUsedAt   = "used_at"
+)
+
+// saveScript stores a token and lets its family live as long as its newest token. A rotation
+// only extends the family, so one racing a revocation can't bring the family back.
+var saveScript = redis.NewScript(`
+if ARGV[4] == "1" then
+	redis.call("SET", KEYS[2], ARGV[1], "PX", ARGV[3])
+elseif redis.call("PEXPIRE", KEYS[2], ARGV[3]) == 0 then
+	return 0
+end
+
+redis.call("HSET", KEYS[1], "user_id", ARGV[1], "family_id", ARGV[2])
+redis.call("PEXPIRE", KEYS[1], ARGV[3])
+return 1
+`)
+
+// markUsedScript sets used_at once, an expired token is not brought back without a TTL
+var markUsedScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return -1
+end
+return redis.call("HSETNX", KEYS[1], "used_at", ARGV[1])
+`)
+
+func (s *redisRefreshStore) Save(ctx context.Context, tokenID string, token RefreshToken, expire time.Duration, newFamily bool) error {
+	flag := "0"
+	if newFamily {
+		flag = "1"
+	}
+	saved, err := saveScript.Run(ctx, s.redisClient, []string{s.tokenKey(tokenID), s.familyKey(token.FamilyID)},
+		token.UserID, token.FamilyID, expire.Milliseconds(), flag).Int64()
+	if err != nil {
+		return errors.Wrap(err, "redisRefreshStore.Save.Run")
+	}
+	if saved == 0 {
+		return httpErr.ErrInvalidRefreshToken
+	}
+	return nil
+}
+
+func (s *redisRefreshStore) Get(ctx context.Context, tokenID string) (*RefreshToken, error) {
+	fields, err := s.redisClient.HGetAll(ctx, s.tokenKey(tokenID)).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "redisRefreshStore.Get.HGetAll")
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	_, used := fields[refreshFieldUsedAt]
+	return &RefreshToken{
+		UserID:   fields[refreshFieldUserID],
+		FamilyID: fields[refreshFieldFamilyID],
+		Used:     used,
+	}, nil
+}
+
+func (s *redisRefreshStore) MarkUsed(ctx context.Context, tokenID string) (bool, error) {
+	result, err := markUsedScript.Run(ctx, s.redisClient, []string{s.tokenKey(tokenID)}, time.Now().Unix()).Int64()
+	if err != nil {
+		return false, errors.Wrap(err, "redisRefreshStore.MarkUsed.Run")
+	}
+	if result < 0 {
+		return false, httpErr.ErrInvalidRefreshToken
+	}
+	return result == 1, nil
+}
+
+func (s *redisRefreshStore) IsFamilyActive(ctx context.Context, familyID string) (bool, error) {
+	n, err := s.redisClient.Exists(ctx, s.familyKey(familyID)).Result()
+	if err != nil {
+		return false, errors.Wrap(err, "redisRefreshStore.IsFamilyActive.Exists")
+	}
+	return n > 0, nil
+}
+
+func (s *redisRefreshStore) RevokeFamily(ctx context.Context, familyID string) error {
+	if err := s.redisClient.Del(ctx, s.familyKey(familyID)).Err(); err != nil {
+		return errors.Wrap(err, "redisRefreshStore.RevokeFamily.Del")
+	}
+	return nil
+}
+
+func (s *redisRefreshStore) tokenKey(tokenID string) string {
+	return s.prefix + ":token:" + tokenID
+}
+
+func (s *redisRefreshStore) familyKey(familyID string) string {
+	return s.prefix + ":family:" + familyID
+}
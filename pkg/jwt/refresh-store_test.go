@@ -0,0 +1,155 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return mr, client
+}
+
+func newTestManager(t *testing.T, client *redis.Client) *Manager {
+	t.Helper()
+
+	key, err := NewHMACKey(defaultHMACKeyID, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := NewKeySet(defaultHMACKeyID, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewManager(keys, config.Jwt{
+		Issuer:             "evonix",
+		Audience:           []string{"evonix-web"},
+		AccessTokenExpire:  time.Minute,
+		RefreshTokenExpire: time.Hour,
+	}, NewRedisRefreshStore(client, "refresh"), NewRedisRevocationStore(client, "revoked"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestRefreshRotation(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	m := newTestManager(t, client)
+
+	login, err := m.NewTokens(ctx, Identity{UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	issued := []Tokens{login}
+
+	// Every step presents the refresh token of an earlier pair, a successful one adds a pair
+	tests := []struct {
+		name    string
+		pair    int
+		wantErr error
+	}{
+		{"rotate the login token", 0, nil},
+		{"rotate the rotated token", 1, nil},
+		{"reuse an already rotated token", 0, httpErr.ErrRefreshTokenReused},
+		{"newest token of the revoked family", 2, httpErr.ErrInvalidRefreshToken},
+	}
+
+	for _, tt := range tests {
+		rotated, err := m.Refresh(ctx, issued[tt.pair].RefreshToken)
+		if !errors.Is(err, tt.wantErr) {
+			t.Fatalf("%s: Refresh() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if err == nil {
+			issued = append(issued, rotated)
+		}
+	}
+}
+
+func TestRefreshStoreSave(t *testing.T) {
+	ctx := context.Background()
+	mr, client := newTestRedis(t)
+	store := NewRedisRefreshStore(client, "refresh")
+	token := RefreshToken{UserID: "user", FamilyID: "family"}
+
+	if err := store.Save(ctx, "rotated", token, time.Hour, false); !errors.Is(err, httpErr.ErrInvalidRefreshToken) {
+		t.Fatalf("Save() of a rotation without a family error = %v, want %v", err, httpErr.ErrInvalidRefreshToken)
+	}
+	if mr.Exists("refresh:token:rotated") {
+		t.Fatal("Save() stored a token of a missing family")
+	}
+
+	if err := store.Save(ctx, "login", token, time.Hour, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(ctx, "rotated", token, 2*time.Hour, false); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := mr.TTL("refresh:family:family"); ttl != 2*time.Hour {
+		t.Fatalf("family TTL = %s, want it extended to %s", ttl, 2*time.Hour)
+	}
+
+	// A rotation racing a revocation must not bring the family back
+	if err := store.RevokeFamily(ctx, "family"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(ctx, "raced", token, time.Hour, false); !errors.Is(err, httpErr.ErrInvalidRefreshToken) {
+		t.Fatalf("Save() after RevokeFamily() error = %v, want %v", err, httpErr.ErrInvalidRefreshToken)
+	}
+	if active, err := store.IsFamilyActive(ctx, "family"); err != nil || active {
+		t.Fatalf("IsFamilyActive() = %v, %v, want false", active, err)
+	}
+}
+
+func TestRefreshStoreMarkUsed(t *testing.T) {
+	ctx := context.Background()
+	mr, client := newTestRedis(t)
+	store := NewRedisRefreshStore(client, "refresh")
+
+	if err := store.Save(ctx, "token", RefreshToken{UserID: "user", FamilyID: "family"}, time.Hour, true); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		tokenID   string
+		wantFirst bool
+		wantErr   error
+	}{
+		{"first use", "token", true, nil},
+		{"second use", "token", false, nil},
+		{"unknown token", "missing", false, httpErr.ErrInvalidRefreshToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := store.MarkUsed(ctx, tt.tokenID)
+			if !errors.Is(err, tt.wantErr) || first != tt.wantFirst {
+				t.Fatalf("MarkUsed() = %v, %v, want %v, %v", first, err, tt.wantFirst, tt.wantErr)
+			}
+		})
+	}
+
+	// An expired token stays gone instead of coming back as a hash without TTL
+	mr.FastForward(2 * time.Hour)
+	if _, err := store.MarkUsed(ctx, "token"); !errors.Is(err, httpErr.ErrInvalidRefreshToken) {
+		t.Fatalf("MarkUsed() of an expired token error = %v, want %v", err, httpErr.ErrInvalidRefreshToken)
+	}
+	if mr.Exists("refresh:token:token") {
+		t.Fatal("MarkUsed() recreated an expired token")
+	}
+}
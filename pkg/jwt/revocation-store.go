@@ -0,0 +1,77 @@
+package jwt
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+)
+
+// RevocationStore keeps the tokens that must be rejected before they expire, either one
+// token by its jti or every token of a user issued up to a point in time
+type RevocationStore interface {
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	// RevokeUser revokes every token of the user issued at or before the given time,
+	// expire should cover the longest token lifetime
+	RevokeUser(ctx context.Context, userID string, before time.Time, expire time.Duration) error
+	IsRevoked(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error)
+}
+
+type redisRevocationStore struct {
+	redisClient *redis.Client
+	prefix      string
+}
+
+// NewRedisRevocationStore creates a RevocationStore backed by redis, keys are namespaced with prefix
+func NewRedisRevocationStore(redisClient *redis.Client, prefix string) *redisRevocationStore {
+	return &redisRevocationStore{redisClient: redisClient, prefix: prefix}
+}
+
+func (s *redisRevocationStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := s.redisClient.Set(ctx, s.tokenKey(tokenID), 1, ttl).Err(); err != nil {
+		return errors.Wrap(err, "redisRevocationStore.RevokeToken.Set")
+	}
+	return nil
+}
+
+func (s *redisRevocationStore) RevokeUser(ctx context.Context, userID string, before time.Time, expire time.Duration) error {
+	if err := s.redisClient.Set(ctx, s.userKey(userID), before.UnixMicro(), expire).Err(); err != nil {
+		return errors.Wrap(err, "redisRevocationStore.RevokeUser.Set")
+	}
+	return nil
+}
+
+func (s *redisRevocationStore) IsRevoked(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error) {
+	values, err := s.redisClient.MGet(ctx, s.tokenKey(tokenID), s.userKey(userID)).Result()
+	if err != nil {
+		return false, errors.Wrap(err, "redisRevocationStore.IsRevoked.MGet")
+	}
+
+	if values[0] != nil {
+		return true, nil
+	}
+	if before, ok := values[1].(string); ok {
+		beforeMicro, err := strconv.ParseInt(before, 10, 64)
+		if err != nil {
+			return false, errors.Wrap(err, "redisRevocationStore.IsRevoked.ParseInt")
+		}
+		// iat carries microseconds, a login right after the revocation keeps its tokens
+		return issuedAt.UnixMicro() <= beforeMicro, nil
+	}
+
+	return false, nil
+}
+
+func (s *redisRevocationStore) tokenKey(tokenID string) string {
+	return s.prefix + ":jti:" + tokenID
+}
+
+func (s *redisRevocationStore) userKey(userID string) string {
+	return s.prefix + ":user:" + userID
+}
@@ -0,0 +1,84 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
+)
+
+func TestIsRevoked(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	store := NewRedisRevocationStore(client, "revoked")
+
+	cutoff := time.Date(2026, 10, 18, 12, 0, 0, int(400*time.Millisecond), time.UTC)
+	if err := store.RevokeUser(ctx, "user", cutoff, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RevokeToken(ctx, "stolen", time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		tokenID  string
+		userID   string
+		issuedAt time.Time
+		want     bool
+	}{
+		{"issued a second before", "a", "user", cutoff.Add(-time.Second), true},
+		{"issued earlier in the same second", "b", "user", cutoff.Add(-300 * time.Millisecond), true},
+		{"issued at the cutoff", "c", "user", cutoff, true},
+		{"issued a millisecond after", "d", "user", cutoff.Add(time.Millisecond), false},
+		{"issued later in the same second", "e", "user", cutoff.Add(500 * time.Millisecond), false},
+		{"other user", "f", "other", cutoff.Add(-time.Second), false},
+		{"revoked token", "stolen", "other", cutoff.Add(time.Second), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.IsRevoked(ctx, tt.tokenID, tt.userID, tt.issuedAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("IsRevoked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevokeUserKeepsLaterLogin(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	m := newTestManager(t, client)
+
+	before, err := m.NewTokens(ctx, Identity{UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.RevokeUser(ctx, "user"); err != nil {
+		t.Fatal(err)
+	}
+	// Right away like the login after a password reset, usually within the same second
+	after, err := m.NewTokens(ctx, Identity{UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = m.Verify(ctx, before.AccessToken); !errors.Is(err, httpErr.ErrRevokedJWTToken) {
+		t.Fatalf("Verify() of a revoked token error = %v, want %v", err, httpErr.ErrRevokedJWTToken)
+	}
+	if _, err = m.Refresh(ctx, before.RefreshToken); !errors.Is(err, httpErr.ErrInvalidRefreshToken) {
+		t.Fatalf("Refresh() of a revoked token error = %v, want %v", err, httpErr.ErrInvalidRefreshToken)
+	}
+	if _, err = m.Verify(ctx, after.AccessToken); err != nil {
+		t.Fatalf("Verify() of the later login error = %v", err)
+	}
+	if _, err = m.Refresh(ctx, after.RefreshToken); err != nil {
+		t.Fatalf("Refresh() of the later login error = %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+)
+
+type fileMailer struct {
+	dir  string
+	from *mail.Address
+}
+
+// NewFileMailer creates a Mailer that writes every message as an .eml file, for development
+func NewFileMailer(cfg config.Mailer) (Mailer, error) {
+	if cfg.Directory == "" {
+		return nil, errors.New("empty mail directory")
+	}
+	if err := os.MkdirAll(cfg.Directory, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &fileMailer{dir: cfg.Directory, from: &mail.Address{Name: cfg.FromName, Address: cfg.From}}, nil
+}
+
+func (m *fileMailer) Send(ctx context.Context, msg *Message) error {
+	body, err := buildMIME(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405.000000000"), randomHex(4))
+	return os.WriteFile(filepath.Join(m.dir, name), body, 0o640)
+}
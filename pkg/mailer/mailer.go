@@ -0,0 +1,58 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+)
+
+// Mailer drivers
+const (
+	DriverSMTP = "smtp"
+	DriverFile = "file"
+	DriverLog  = "log"
+)
+
+// Message is a single email, HTML is optional
+type Message struct {
+	To      []string `json:"to,omitempty"`
+	Subject string   `json:"subject"`
+	Text    string   `json:"text"`
+	HTML    string   `json:"html,omitempty"`
+}
+
+// Mailer sends emails
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewMailer creates the Mailer of the configured driver
+func NewMailer(cfg config.Mailer, logger logger.Logger) (Mailer, error) {
+	switch cfg.Driver {
+	case DriverSMTP:
+		return NewSMTPMailer(cfg)
+	case DriverFile:
+		return NewFileMailer(cfg)
+	case DriverLog, "":
+		return NewLogMailer(logger), nil
+	default:
+		return nil, fmt.Errorf("unknown mailer driver %q", cfg.Driver)
+	}
+}
+
+type logMailer struct {
+	logger logger.Logger
+}
+
+// NewLogMailer creates a Mailer that only logs messages, for development
+func NewLogMailer(logger logger.Logger) Mailer {
+	return &logMailer{logger: logger}
+}
+
+func (m *logMailer) Send(ctx context.Context, msg *Message) error {
+	m.logger.Infof("Mail To: %s, Subject: %s\n%s", strings.Join(msg.To, ", "), msg.Subject, msg.Text)
+	return nil
+}
@@ -0,0 +1,150 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+)
+
+const (
+	defaultQueueSize    = 256
+	defaultQueueWorkers = 1
+	defaultRetryBackoff = time.Second * 2
+	maxRetryBackoff     = time.Minute
+)
+
+// ErrQueueClosed is returned by Send once the queue is shutting down
+var ErrQueueClosed = errors.New("mail queue closed")
+
+// Queue is a Mailer that hands messages to background workers, a failed delivery is retried
+// with exponential backoff until MaxRetries is reached and then logged
+type Queue struct {
+	mailer       Mailer
+	logger       logger.Logger
+	maxRetries   int
+	retryBackoff time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	jobs   chan *Message
+	quit   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewQueue creates a Queue in front of the mailer and starts its workers
+func NewQueue(mailer Mailer, cfg config.Mailer, logger logger.Logger) *Queue {
+	size, workers, backoff := cfg.QueueSize, cfg.Workers, cfg.RetryBackoff
+	if size <= 0 {
+		size = defaultQueueSize
+	}
+	if workers <= 0 {
+		workers = defaultQueueWorkers
+	}
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	q := &Queue{
+		mailer:       mailer,
+		logger:       logger,
+		maxRetries:   cfg.MaxRetries,
+		retryBackoff: backoff,
+		jobs:         make(chan *Message, size),
+		quit:         make(chan struct{}),
+	}
+	for i := 0; i < workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+
+	return q
+}
+
+// Send enqueues the message, it only blocks while the queue is full
+func (q *Queue) Send(ctx context.Context, msg *Message) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case q.jobs <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting messages and waits for the queued ones to be delivered. Once ctx is
+// done pending retries are given up and the remaining messages are dropped.
+func (q *Queue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	close(q.jobs)
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		close(q.quit)
+		return ctx.Err()
+	}
+}
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+
+	for msg := range q.jobs {
+		select {
+		case <-q.quit:
+			q.logger.Errorf("Mail queue closed, dropped mail To: %s, Subject: %s", strings.Join(msg.To, ", "), msg.Subject)
+			continue
+		default:
+		}
+		q.deliver(msg)
+	}
+}
+
+func (q *Queue) deliver(msg *Message) {
+	backoff := q.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err := q.mailer.Send(context.Background(), msg)
+		if err == nil {
+			return
+		}
+		if attempt >= q.maxRetries {
+			q.logger.Errorf("Mail failed after %d attempts, To: %s, Subject: %s, Error: %s",
+				attempt+1, strings.Join(msg.To, ", "), msg.Subject, err)
+			return
+		}
+		q.logger.Warnf("Mail attempt %d failed, retrying in %s, Subject: %s, Error: %s", attempt+1, backoff, msg.Subject, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-q.quit:
+			q.logger.Errorf("Mail queue closed, gave up on To: %s, Subject: %s", strings.Join(msg.To, ", "), msg.Subject)
+			return
+		}
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
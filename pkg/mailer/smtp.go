@@ -0,0 +1,125 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+)
+
+const defaultSMTPTimeout = time.Second * 15
+
+type smtpMailer struct {
+	addr        string
+	host        string
+	from        *mail.Address
+	auth        smtp.Auth
+	implicitTLS bool
+	timeout     time.Duration
+}
+
+// NewSMTPMailer creates a Mailer that delivers through an SMTP relay, using STARTTLS when the
+// server offers it or implicit TLS (port 465) when configured
+func NewSMTPMailer(cfg config.Mailer) (Mailer, error) {
+	if cfg.Host == "" || cfg.Port == 0 {
+		return nil, errors.New("empty smtp host or port")
+	}
+	if cfg.From == "" {
+		return nil, errors.New("empty mail sender")
+	}
+
+	m := &smtpMailer{
+		addr:        net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		host:        cfg.Host,
+		from:        &mail.Address{Name: cfg.FromName, Address: cfg.From},
+		implicitTLS: cfg.ImplicitTLS,
+		timeout:     cfg.Timeout,
+	}
+	if cfg.Username != "" {
+		m.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	if m.timeout <= 0 {
+		m.timeout = defaultSMTPTimeout
+	}
+
+	return m, nil
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg *Message) error {
+	body, err := buildMIME(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	conn, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	// net/smtp doesn't take a context, the deadline bounds the whole conversation instead
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if !m.implicitTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err = client.StartTLS(&tls.Config{ServerName: m.host, MinVersion: tls.VersionTLS12}); err != nil {
+				return err
+			}
+		}
+	}
+	if m.auth != nil {
+		if err = client.Auth(m.auth); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(m.from.Address); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		if err = client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(body); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (m *smtpMailer) dial(ctx context.Context) (net.Conn, error) {
+	if m.implicitTLS {
+		dialer := &tls.Dialer{Config: &tls.Config{ServerName: m.host, MinVersion: tls.VersionTLS12}}
+		return dialer.DialContext(ctx, "tcp", m.addr)
+	}
+
+	dialer := &net.Dialer{}
+	return dialer.DialContext(ctx, "tcp", m.addr)
+}
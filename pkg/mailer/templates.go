@@ -0,0 +1,204 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmlTemplate "html/template"
+	"strings"
+	textTemplate "text/template"
+	"time"
+)
+
+// Template names
+const (
+	TemplateEmailVerification = "email_verification"
+	TemplateEmailChangeNotice = "email_change_notice"
+	TemplatePasswordReset     = "password_reset"
+	TemplateBanNotice         = "ban_notice"
+	TemplateNewCountryLogin   = "new_country_login"
+)
+
+// Languages the templates are translated to
+const (
+	LanguageIndonesian = "id"
+	LanguageEnglish    = "en"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// EmailVerificationData fills TemplateEmailVerification
+type EmailVerificationData struct {
+	Username string
+	Link     string
+	Expire   time.Duration
+}
+
+// EmailChangeNoticeData fills TemplateEmailChangeNotice, it goes to the old address
+type EmailChangeNoticeData struct {
+	Username string
+	NewEmail string
+}
+
+// PasswordResetData fills TemplatePasswordReset
+type PasswordResetData struct {
+	Username string
+	Link     string
+	Expire   time.Duration
+}
+
+// BanNoticeData fills TemplateBanNotice, ExpiresAt is nil for a permanent ban
+type BanNoticeData struct {
+	Username  string
+	Reason    string
+	BannedBy  string
+	ExpiresAt *time.Time
+	AppealURL string
+}
+
+// NewCountryLoginData fills TemplateNewCountryLogin, City is empty when only the country is known
+type NewCountryLoginData struct {
+	Username string
+	Country  string
+	City     string
+	IP       string
+	Time     time.Time
+}
+
+// sampleData is what the admin preview renders each template with
+var sampleData = map[string]func() interface{}{
+	TemplateEmailVerification: func() interface{} {
+		return EmailVerificationData{Username: "John_Doe", Link: "https://ucp.evonix-rp.com/verify-email?token=sample", Expire: time.Hour * 48}
+	},
+	TemplateEmailChangeNotice: func() interface{} {
+		return EmailChangeNoticeData{Username: "John_Doe", NewEmail: "john.doe@example.com"}
+	},
+	TemplatePasswordReset: func() interface{} {
+		return PasswordResetData{Username: "John_Doe", Link: "https://ucp.evonix-rp.com/reset-password?token=sample", Expire: time.Minute * 30}
+	},
+	TemplateBanNotice: func() interface{} {
+		expiresAt := time.Now().Add(time.Hour * 72)
+		return BanNoticeData{
+			Username:  "John_Doe",
+			Reason:    "Deathmatch",
+			BannedBy:  "Admin_Evonix",
+			ExpiresAt: &expiresAt,
+			AppealURL: "https://forum.evonix-rp.com/ban-appeals",
+		}
+	},
+	TemplateNewCountryLogin: func() interface{} {
+		return NewCountryLoginData{Username: "John_Doe", Country: "Singapore", City: "Singapore", IP: "203.0.113.7", Time: time.Now()}
+	},
+}
+
+var templateFuncs = map[string]interface{}{
+	"hours":   func(d time.Duration) int { return int(d.Hours()) },
+	"minutes": func(d time.Duration) int { return int(d.Minutes()) },
+	"date":    func(t time.Time) string { return t.Format("02 Jan 2006 15:04 MST") },
+}
+
+type localizedTemplate struct {
+	text *textTemplate.Template
+	html *htmlTemplate.Template
+}
+
+// Templates renders localized emails. Every template has a text file defining "subject" with the
+// plain text body as its root, and an HTML file defining "content" rendered into the language layout.
+type Templates struct {
+	defaultLanguage string
+	templates       map[string]map[string]*localizedTemplate
+}
+
+// NewTemplates parses every embedded template, falling back to defaultLanguage for unknown languages
+func NewTemplates(defaultLanguage string) (*Templates, error) {
+	if !IsSupportedLanguage(defaultLanguage) {
+		defaultLanguage = LanguageIndonesian
+	}
+
+	t := &Templates{defaultLanguage: defaultLanguage, templates: make(map[string]map[string]*localizedTemplate)}
+	for _, lang := range []string{LanguageIndonesian, LanguageEnglish} {
+		t.templates[lang] = make(map[string]*localizedTemplate)
+		for _, name := range TemplateNames() {
+			dir := "templates/" + lang + "/"
+
+			text, err := textTemplate.New(name+".txt").Funcs(templateFuncs).ParseFS(templateFS, dir+name+".txt")
+			if err != nil {
+				return nil, err
+			}
+			if text.Lookup("subject") == nil {
+				return nil, fmt.Errorf("mail template %s%s.txt has no subject", dir, name)
+			}
+
+			html, err := htmlTemplate.New("layout.html").Funcs(templateFuncs).ParseFS(templateFS, dir+"layout.html", dir+name+".html")
+			if err != nil {
+				return nil, err
+			}
+
+			t.templates[lang][name] = &localizedTemplate{text: text, html: html}
+		}
+	}
+
+	return t, nil
+}
+
+// TemplateNames lists every template
+func TemplateNames() []string {
+	return []string{TemplateEmailVerification, TemplateEmailChangeNotice, TemplatePasswordReset, TemplateBanNotice, TemplateNewCountryLogin}
+}
+
+// IsSupportedLanguage reports whether the templates are translated to lang
+func IsSupportedLanguage(lang string) bool {
+	return lang == LanguageIndonesian || lang == LanguageEnglish
+}
+
+// Render renders a template into a message without recipients
+func (t *Templates) Render(name, lang string, data interface{}) (*Message, error) {
+	if !IsSupportedLanguage(lang) {
+		lang = t.defaultLanguage
+	}
+	tmpl, ok := t.templates[lang][name]
+	if !ok {
+		return nil, fmt.Errorf("unknown mail template %q", name)
+	}
+
+	subject, text, html := &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
+	if err := tmpl.text.ExecuteTemplate(subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := tmpl.text.Execute(text, data); err != nil {
+		return nil, err
+	}
+	if err := tmpl.html.Execute(html, data); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+// Preview renders a template with its sample data
+func (t *Templates) Preview(name, lang string) (*Message, error) {
+	sample, ok := sampleData[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown mail template %q", name)
+	}
+	return t.Render(name, lang, sample())
+}
+
+type languageCtxKey struct{}
+
+// ContextWithLanguage stores the preferred mail language of the current request
+func ContextWithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageCtxKey{}, lang)
+}
+
+// LanguageFromContext returns the preferred mail language, empty means the default language
+func LanguageFromContext(ctx context.Context) string {
+	lang, _ := ctx.Value(languageCtxKey{}).(string)
+	return lang
+}
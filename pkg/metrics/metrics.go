@@ -1,7 +1,7 @@
 package metrics
 
 import (
-	"log"
+	"net/http"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
@@ -12,15 +12,22 @@ import (
 type Metrics interface {
 	IncHits(status int, method, path string)
 	ObserveResponseTime(status int, method, path string, observeTime float64)
+	IncLoginFailures()
+	// IncLockouts counts lockouts by scope, account or ip
+	IncLockouts(scope string)
+	IncSuspiciousActivity(reason string)
 }
 
 type PrometheusMetrics struct {
-	HitsTotal prometheus.Counter
-	Hits      *prometheus.CounterVec
-	Times     *prometheus.HistogramVec
+	HitsTotal          prometheus.Counter
+	Hits               *prometheus.CounterVec
+	Times              *prometheus.HistogramVec
+	LoginFailures      prometheus.Counter
+	Lockouts           *prometheus.CounterVec
+	SuspiciousActivity *prometheus.CounterVec
 }
 
-func CreateMetrics(address string, name string) (Metrics, error) {
+func CreateMetrics(name string) (Metrics, error) {
 	var metr PrometheusMetrics
 	metr.HitsTotal = prometheus.NewCounter(prometheus.CounterOpts{
 		Name: name + "_hits_total",
@@ -52,22 +59,55 @@ func CreateMetrics(address string, name string) (Metrics, error) {
 		return nil, err
 	}
 
-	if err := prometheus.Register(prometheus.NewBuildInfoCollector()); err != nil {
+	metr.LoginFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: name + "_login_failures_total",
+	})
+
+	if err := prometheus.Register(metr.LoginFailures); err != nil {
+		return nil, err
+	}
+
+	metr.Lockouts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name + "_login_lockouts_total",
+		},
+		[]string{"scope"},
+	)
+
+	if err := prometheus.Register(metr.Lockouts); err != nil {
+		return nil, err
+	}
+
+	metr.SuspiciousActivity = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name + "_suspicious_activity_total",
+		},
+		[]string{"reason"},
+	)
+
+	if err := prometheus.Register(metr.SuspiciousActivity); err != nil {
 		return nil, err
 	}
 
-	go func() {
-		router := echo.New()
-		router.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
-		log.Printf("metrics server is running on port: %s", address)
-		if err := router.Start(address); err != nil {
-			log.Fatal(err)
-		}
-	}()
+	if err := prometheus.Register(prometheus.NewBuildInfoCollector()); err != nil {
+		return nil, err
+	}
 
 	return &metr, nil
 }
 
+// NewServer creates the http server exposing the /metrics endpoint, the caller owns its lifecycle
+func NewServer(address string) *http.Server {
+	router := echo.New()
+	router.HideBanner = true
+	router.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+
+	return &http.Server{
+		Addr:    address,
+		Handler: router,
+	}
+}
+
 func (metr *PrometheusMetrics) IncHits(status int, method, path string) {
 	metr.HitsTotal.Inc()
 	metr.Hits.WithLabelValues(strconv.Itoa(status), method, path).Inc()
@@ -76,3 +116,15 @@ func (metr *PrometheusMetrics) IncHits(status int, method, path string) {
 func (metr *PrometheusMetrics) ObserveResponseTime(status int, method, path string, observeTime float64) {
 	metr.Times.WithLabelValues(strconv.Itoa(status), method, path).Observe(observeTime)
 }
+
+func (metr *PrometheusMetrics) IncLoginFailures() {
+	metr.LoginFailures.Inc()
+}
+
+func (metr *PrometheusMetrics) IncLockouts(scope string) {
+	metr.Lockouts.WithLabelValues(scope).Inc()
+}
+
+func (metr *PrometheusMetrics) IncSuspiciousActivity(reason string) {
+	metr.SuspiciousActivity.WithLabelValues(reason).Inc()
+}
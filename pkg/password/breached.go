@@ -0,0 +1,76 @@
+package password
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Lengths of the k-anonymity prefix and the suffix in hex, as used by Have I Been Pwned
+const (
+	breachedPrefixLength = 5
+	breachedSuffixLength = sha1.Size*2 - breachedPrefixLength
+)
+
+// BreachedList is a sorted set of SHA-1 hashes of breached passwords
+type BreachedList struct {
+	hashes [][sha1.Size]byte
+}
+
+// LoadBreachedList reads a breached password file. Every line is either a full SHA-1 hash or
+// a prefix and suffix pair (PREFIX:SUFFIX), optionally followed by :COUNT. Empty lines and
+// lines starting with # are skipped.
+func LoadBreachedList(path string) (*BreachedList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "password.LoadBreachedList.Open")
+	}
+	defer file.Close()
+
+	list := &BreachedList{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		digest := parts[0]
+		if len(parts) > 1 && len(parts[0]) == breachedPrefixLength && len(parts[1]) == breachedSuffixLength {
+			digest = parts[0] + parts[1]
+		}
+
+		var sum [sha1.Size]byte
+		if len(digest) != sha1.Size*2 {
+			return nil, errors.Errorf("password.LoadBreachedList: invalid entry on line %d", line)
+		}
+		if _, err = hex.Decode(sum[:], []byte(digest)); err != nil {
+			return nil, errors.Wrapf(err, "password.LoadBreachedList: invalid entry on line %d", line)
+		}
+		list.hashes = append(list.hashes, sum)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "password.LoadBreachedList.Scan")
+	}
+
+	sort.Slice(list.hashes, func(i, j int) bool {
+		return bytes.Compare(list.hashes[i][:], list.hashes[j][:]) < 0
+	})
+	return list, nil
+}
+
+// Contains reports whether the password is in the list
+func (l *BreachedList) Contains(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	i := sort.Search(len(l.hashes), func(i int) bool {
+		return bytes.Compare(l.hashes[i][:], sum[:]) >= 0
+	})
+	return i < len(l.hashes) && l.hashes[i] == sum
+}
@@ -0,0 +1,151 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/iamaul/go-evonix-backend-api/config"
+
+	"github.com/nbutton23/zxcvbn-go"
+)
+
+// Policy rules, the frontend keys its messages on them
+const (
+	RuleMinLength    = "min_length"
+	RuleMaxLength    = "max_length"
+	RuleLower        = "lowercase"
+	RuleUpper        = "uppercase"
+	RuleDigit        = "digit"
+	RuleSymbol       = "symbol"
+	RuleStrength     = "strength"
+	RulePersonalInfo = "personal_info"
+	RuleBreached     = "breached"
+)
+
+// minPersonalLength keeps short name parts such as "Al" from rejecting half of all passwords
+const minPersonalLength = 3
+
+// Violation is a broken rule of the policy
+type Violation struct {
+	Rule    string                 `json:"rule"`
+	Message string                 `json:"message"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+}
+
+// Policy checks new passwords against the configured rules and the breached password list
+type Policy struct {
+	cfg      config.PasswordPolicy
+	breached *BreachedList
+}
+
+// NewPolicy creates a Policy and loads the breached password list when one is configured
+func NewPolicy(cfg config.PasswordPolicy) (*Policy, error) {
+	policy := &Policy{cfg: cfg}
+	if cfg.BreachedList != "" {
+		breached, err := LoadBreachedList(cfg.BreachedList)
+		if err != nil {
+			return nil, err
+		}
+		policy.breached = breached
+	}
+	return policy, nil
+}
+
+// Validate returns every rule the password breaks, personal is the username of the account,
+// SA-MP names like Firstname_Lastname are checked part by part too
+func (p *Policy) Validate(password string, personal ...string) []Violation {
+	var violations []Violation
+
+	length := utf8.RuneCountInString(password)
+	if p.cfg.MinLength > 0 && length < p.cfg.MinLength {
+		violations = append(violations, Violation{
+			Rule:    RuleMinLength,
+			Message: fmt.Sprintf("password must be at least %d characters long", p.cfg.MinLength),
+			Params:  map[string]interface{}{"min": p.cfg.MinLength},
+		})
+	}
+	if p.cfg.MaxLength > 0 && length > p.cfg.MaxLength {
+		violations = append(violations, Violation{
+			Rule:    RuleMaxLength,
+			Message: fmt.Sprintf("password must be at most %d characters long", p.cfg.MaxLength),
+			Params:  map[string]interface{}{"max": p.cfg.MaxLength},
+		})
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if p.cfg.RequireLower && !lower {
+		violations = append(violations, Violation{Rule: RuleLower, Message: "password must contain a lowercase letter"})
+	}
+	if p.cfg.RequireUpper && !upper {
+		violations = append(violations, Violation{Rule: RuleUpper, Message: "password must contain an uppercase letter"})
+	}
+	if p.cfg.RequireDigit && !digit {
+		violations = append(violations, Violation{Rule: RuleDigit, Message: "password must contain a digit"})
+	}
+	if p.cfg.RequireSymbol && !symbol {
+		violations = append(violations, Violation{Rule: RuleSymbol, Message: "password must contain a symbol"})
+	}
+
+	words := personalWords(personal)
+	lowered := strings.ToLower(password)
+	for _, word := range words {
+		if strings.Contains(lowered, word) {
+			violations = append(violations, Violation{
+				Rule:    RulePersonalInfo,
+				Message: "password must not contain your username",
+			})
+			break
+		}
+	}
+
+	if p.cfg.MinScore > 0 {
+		if score := zxcvbn.PasswordStrength(password, words).Score; score < p.cfg.MinScore {
+			violations = append(violations, Violation{
+				Rule:    RuleStrength,
+				Message: "password is too easy to guess",
+				Params:  map[string]interface{}{"score": score, "min_score": p.cfg.MinScore},
+			})
+		}
+	}
+
+	if p.breached != nil && p.breached.Contains(password) {
+		violations = append(violations, Violation{
+			Rule:    RuleBreached,
+			Message: "password appeared in a data breach, choose another one",
+		})
+	}
+
+	return violations
+}
+
+// personalWords lowercases the personal inputs and adds the parts of underscore separated names
+func personalWords(personal []string) []string {
+	words := make([]string, 0, len(personal)*3)
+	for _, input := range personal {
+		input = strings.ToLower(strings.TrimSpace(input))
+		if len(input) < minPersonalLength {
+			continue
+		}
+		words = append(words, input)
+		for _, part := range strings.Split(input, "_") {
+			if part != input && len(part) >= minPersonalLength {
+				words = append(words, part)
+			}
+		}
+	}
+	return words
+}
@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
+)
+
+// FallbackLimiter uses the primary limiter and switches to the fallback while the primary
+// fails, so an outage neither lets every request through nor rejects them all. The switch in
+// both directions is logged once.
+type FallbackLimiter struct {
+	primary  Limiter
+	fallback Limiter
+	logger   logger.Logger
+	degraded int32
+}
+
+// NewFallbackLimiter creates a limiter falling back from primary to fallback
+func NewFallbackLimiter(primary, fallback Limiter, logger logger.Logger) *FallbackLimiter {
+	return &FallbackLimiter{primary: primary, fallback: fallback, logger: logger}
+}
+
+func (l *FallbackLimiter) Allow(ctx context.Context, key string, limit int, period time.Duration) (Result, error) {
+	result, err := l.primary.Allow(ctx, key, limit, period)
+	if err == nil {
+		if atomic.CompareAndSwapInt32(&l.degraded, 1, 0) {
+			l.logger.Info("Rate limiter recovered, using the primary limiter again")
+		}
+		return result, nil
+	}
+
+	if atomic.CompareAndSwapInt32(&l.degraded, 0, 1) {
+		l.logger.Errorf("Rate limiter failed, falling back to the in-process limiter: %s", err)
+	}
+	return l.fallback.Allow(ctx, key, limit, period)
+}
@@ -0,0 +1,46 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memoryCleanupInterval = time.Minute
+
+// MemoryLimiter keeps the state of every key in process, it only limits per instance
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	tats    map[string]time.Time
+	cleaned time.Time
+}
+
+// NewMemoryLimiter creates an in-process limiter
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{tats: make(map[string]time.Time), cleaned: time.Now()}
+}
+
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, limit int, period time.Duration) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.cleanup(now)
+
+	result, tat := gcra(now, l.tats[key], limit, period)
+	l.tats[key] = tat
+	return result, nil
+}
+
+// cleanup drops keys that are back to their full limit, a missing key behaves the same
+func (l *MemoryLimiter) cleanup(now time.Time) {
+	if now.Sub(l.cleaned) < memoryCleanupInterval {
+		return
+	}
+	for key, tat := range l.tats {
+		if !tat.After(now) {
+			delete(l.tats, key)
+		}
+	}
+	l.cleaned = now
+}
@@ -0,0 +1,49 @@
+// Package ratelimit implements GCRA (generic cell rate algorithm) limiters: a key may make Limit
+// requests per Period, spread out or as a burst, and regains one request every Period/Limit.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Result is the state of a key after a request
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long a denied key has to wait for its next request
+	RetryAfter time.Duration
+	// Reset is how long until the key is back to its full limit
+	Reset time.Duration
+}
+
+// Limiter takes one request from the budget of a key
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit int, period time.Duration) (Result, error)
+}
+
+// gcra computes the new theoretical arrival time of a key, shared by every limiter so they agree
+func gcra(now, tat time.Time, limit int, period time.Duration) (Result, time.Time) {
+	interval := period / time.Duration(limit)
+	if tat.Before(now) {
+		tat = now
+	}
+
+	newTat := tat.Add(interval)
+	allowAt := newTat.Add(-period)
+	if now.Before(allowAt) {
+		return Result{
+			Limit:      limit,
+			RetryAfter: allowAt.Sub(now),
+			Reset:      tat.Sub(now),
+		}, tat
+	}
+
+	return Result{
+		Allowed:   true,
+		Limit:     limit,
+		Remaining: int(now.Sub(allowAt) / interval),
+		Reset:     newTat.Sub(now),
+	}, newTat
+}
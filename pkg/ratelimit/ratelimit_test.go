@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGCRA(t *testing.T) {
+	const (
+		limit  = 3
+		period = 3 * time.Second
+	)
+	start := time.Unix(1700000000, 0)
+
+	// Every step runs against the tat left by the previous one
+	tests := []struct {
+		name       string
+		at         time.Duration
+		allowed    bool
+		remaining  int
+		retryAfter time.Duration
+	}{
+		{"first request", 0, true, 2, 0},
+		{"burst", 0, true, 1, 0},
+		{"burst exhausts the limit", 0, true, 0, 0},
+		{"over the limit", 0, false, 0, time.Second},
+		{"still over the limit", 500 * time.Millisecond, false, 0, 500 * time.Millisecond},
+		{"one request regained", time.Second, true, 0, 0},
+		{"full limit after the period", 10 * time.Second, true, 2, 0},
+	}
+
+	var tat time.Time
+	for _, tt := range tests {
+		result, newTat := gcra(start.Add(tt.at), tat, limit, period)
+		if result.Allowed != tt.allowed || result.Remaining != tt.remaining || result.RetryAfter != tt.retryAfter {
+			t.Fatalf("%s: got allowed=%v remaining=%d retryAfter=%s, want allowed=%v remaining=%d retryAfter=%s",
+				tt.name, result.Allowed, result.Remaining, result.RetryAfter, tt.allowed, tt.remaining, tt.retryAfter)
+		}
+		if result.Limit != limit {
+			t.Fatalf("%s: limit = %d, want %d", tt.name, result.Limit, limit)
+		}
+		// A denied request must not use up any budget
+		if !tt.allowed && !newTat.Equal(tat) {
+			t.Fatalf("%s: denied request moved the tat", tt.name)
+		}
+		tat = newTat
+	}
+}
+
+func TestMemoryLimiterKeysAreIndependent(t *testing.T) {
+	l := NewMemoryLimiter()
+	for i := 0; i < 2; i++ {
+		if result, _ := l.Allow(context.Background(), "a", 2, time.Minute); !result.Allowed {
+			t.Fatalf("request %d of a denied", i+1)
+		}
+	}
+	if result, _ := l.Allow(context.Background(), "a", 2, time.Minute); result.Allowed {
+		t.Fatal("third request of a allowed")
+	}
+	if result, _ := l.Allow(context.Background(), "b", 2, time.Minute); !result.Allowed {
+		t.Fatal("b denied by the requests of a")
+	}
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// gcraScript runs the GCRA step atomically on the redis clock, times are in microseconds
+var gcraScript = redis.NewScript(`
+local interval = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local clock = redis.call("TIME")
+local now = tonumber(clock[1]) * 1000000 + tonumber(clock[2])
+
+local tat = tonumber(redis.call("GET", KEYS[1]) or now)
+if tat < now then
+	tat = now
+end
+
+local new_tat = tat + interval
+local allow_at = new_tat - period
+if now < allow_at then
+	return {0, 0, allow_at - now, tat - now}
+end
+
+redis.call("SET", KEYS[1], new_tat, "PX", math.ceil((new_tat - now) / 1000))
+return {1, math.floor((now - allow_at) / interval), 0, new_tat - now}
+`)
+
+// RedisLimiter shares the state of every key between all api instances
+type RedisLimiter struct {
+	redisClient *redis.Client
+	prefix      string
+}
+
+// NewRedisLimiter creates a limiter storing its keys under prefix
+func NewRedisLimiter(redisClient *redis.Client, prefix string) *RedisLimiter {
+	return &RedisLimiter{redisClient: redisClient, prefix: prefix}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit int, period time.Duration) (Result, error) {
+	interval := period / time.Duration(limit)
+	values, err := gcraScript.Run(ctx, l.redisClient, []string{l.prefix + ":" + key},
+		interval.Microseconds(), period.Microseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      limit,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Microsecond,
+		Reset:      time.Duration(values[3]) * time.Microsecond,
+	}, nil
+}
@@ -13,8 +13,8 @@ func init() {
 	sanitizer = bluemonday.UGCPolicy()
 }
 
-// Sanitize json
-func SanitizeJSON(s []byte) ([]byte, error) {
+// Sanitize json, the string values of the raw keys are kept as they are
+func SanitizeJSON(s []byte, raw ...string) ([]byte, error) {
 	d := json.NewDecoder(bytes.NewReader(s))
 	d.UseNumber()
 	var i interface{}
@@ -22,21 +22,27 @@ func SanitizeJSON(s []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	sanitize(i)
+	skip := make(map[string]bool, len(raw))
+	for _, key := range raw {
+		skip[key] = true
+	}
+	sanitize(i, skip)
 	return json.MarshalIndent(i, "", "    ")
 }
 
-func sanitize(data interface{}) {
+func sanitize(data interface{}, skip map[string]bool) {
 	switch d := data.(type) {
 	case map[string]interface{}:
 		for k, v := range d {
 			switch tv := v.(type) {
 			case string:
-				d[k] = sanitizer.Sanitize(tv)
+				if !skip[k] {
+					d[k] = sanitizer.Sanitize(tv)
+				}
 			case map[string]interface{}:
-				sanitize(tv)
+				sanitize(tv, skip)
 			case []interface{}:
-				sanitize(tv)
+				sanitize(tv, skip)
 			case nil:
 				delete(d, k)
 			}
@@ -50,11 +56,11 @@ func sanitize(data interface{}) {
 				}
 			case map[string]interface{}:
 				for _, t := range d {
-					sanitize(t)
+					sanitize(t, skip)
 				}
 			case []interface{}:
 				for _, t := range d {
-					sanitize(t)
+					sanitize(t, skip)
 				}
 			}
 		}
@@ -0,0 +1,120 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// base32 of the RFC 6238 SHA-1 test key "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateRFCVectors(t *testing.T) {
+	// The last 6 digits of the 8 digit codes of RFC 6238 appendix B
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		now := time.Unix(tt.unix, 0)
+		step, ok := Validate(tt.code, rfcSecret, now)
+		if !ok {
+			t.Errorf("Validate(%q) at %d: not accepted", tt.code, tt.unix)
+			continue
+		}
+		if step != Step(now) {
+			t.Errorf("Validate(%q) at %d: step %d, want %d", tt.code, tt.unix, step, Step(now))
+		}
+	}
+}
+
+func TestValidateWindow(t *testing.T) {
+	key, err := b32.DecodeString(rfcSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	current := Step(now)
+
+	tests := []struct {
+		name   string
+		offset int64
+		ok     bool
+	}{
+		{"two steps behind", -2, false},
+		{"previous step", -1, true},
+		{"current step", 0, true},
+		{"next step", 1, true},
+		{"two steps ahead", 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(generate(key, current+tt.offset), rfcSecret, now)
+			if ok != tt.ok {
+				t.Fatalf("accepted = %v, want %v", ok, tt.ok)
+			}
+			if ok && step != current+tt.offset {
+				t.Fatalf("step = %d, want %d", step, current+tt.offset)
+			}
+		})
+	}
+}
+
+// A code stays valid for the next step too, Validate must return the step it was made for so
+// callers can refuse a step that was already used
+func TestValidateReplayReturnsOriginalStep(t *testing.T) {
+	key, err := b32.DecodeString(rfcSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issued := time.Unix(1700000000, 0)
+	code := generate(key, Step(issued))
+
+	first, ok := Validate(code, rfcSecret, issued)
+	if !ok {
+		t.Fatal("code not accepted in its own step")
+	}
+	replayed, ok := Validate(code, rfcSecret, issued.Add(Period*time.Second))
+	if !ok {
+		t.Fatal("code not accepted in the next step")
+	}
+	if replayed != first {
+		t.Fatalf("replayed step = %d, want %d", replayed, first)
+	}
+}
+
+func TestValidateMalformed(t *testing.T) {
+	now := time.Unix(59, 0)
+	tests := []struct {
+		name   string
+		code   string
+		secret string
+	}{
+		{"short code", "28708", rfcSecret},
+		{"long code", "2870820", rfcSecret},
+		{"letters", "abcdef", rfcSecret},
+		{"invalid secret", "287082", "not-base32!"},
+		{"other secret", "287082", "JBSWY3DPEHPK3PXP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := Validate(tt.code, tt.secret, now); ok {
+				t.Fatal("accepted")
+			}
+		})
+	}
+}
+
+func TestValidateTrimsAndAcceptsLowercaseSecret(t *testing.T) {
+	if _, ok := Validate(" 287082 ", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", time.Unix(59, 0)); !ok {
+		t.Fatal("not accepted")
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/iamaul/go-evonix-backend-api/config"
+	"github.com/iamaul/go-evonix-backend-api/pkg/caller"
 	httpErr "github.com/iamaul/go-evonix-backend-api/pkg/errors"
 	"github.com/iamaul/go-evonix-backend-api/pkg/logger"
 	"github.com/iamaul/go-evonix-backend-api/pkg/sanitize"
@@ -80,6 +82,19 @@ func CreateSessionCookie(cfg *config.Config, session string) *http.Cookie {
 	}
 }
 
+// CreateCSRFCookie Configure the double-submit csrf cookie
+func CreateCSRFCookie(cfg *config.Config, token string) *http.Cookie {
+	return &http.Cookie{
+		Name:     cfg.CSRF.CookieName,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(cfg.CSRF.Expire.Seconds()),
+		Secure:   cfg.Cookie.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}
+}
+
 // DeleteSessionCookie Delete session
 func DeleteSessionCookie(c echo.Context, sessionName string) {
 	c.SetCookie(&http.Cookie{
@@ -90,22 +105,44 @@ func DeleteSessionCookie(c echo.Context, sessionName string) {
 	})
 }
 
-// CustomerCtxKey is a key used for the User object in the context
-type CustomerCtxKey struct{}
+// PrincipalCtxKey is a key used for the authenticated Principal in the context
+type PrincipalCtxKey struct{}
+
+// GetPrincipalFromCtx Get the authenticated principal from context
+func GetPrincipalFromCtx(ctx context.Context) (*caller.Principal, error) {
+	principal, ok := ctx.Value(PrincipalCtxKey{}).(*caller.Principal)
+	if !ok {
+		return nil, httpErr.ErrUnauthorized
+	}
+
+	return principal, nil
+}
+
+// FingerprintHeader carries the browser fingerprint computed by the UCP frontend
+const FingerprintHeader = "X-Device-Fingerprint"
 
-// Get customer from context
-//func GetCustomerFromCtx(ctx context.Context) (*customer.Customer, error) {
-//	user, ok := ctx.Value(CustomerCtxKey{}).(*customer.Customer)
-//	if !ok {
-//		return nil, httpErr.ErrUnauthorized
-//	}
-//
-//	return user, nil
-//}
+// RequestMetaCtxKey is a key used for the RequestMeta of the request in the context
+type RequestMetaCtxKey struct{}
 
-// GetIPAddress Get user ip address
+// GetRequestMeta Get the ip, user agent, request id and browser fingerprint of the request
+func GetRequestMeta(c echo.Context) caller.RequestMeta {
+	return caller.RequestMeta{
+		IP:          GetIPAddress(c),
+		UserAgent:   c.Request().UserAgent(),
+		RequestID:   GetRequestID(c),
+		Fingerprint: c.Request().Header.Get(FingerprintHeader),
+	}
+}
+
+// GetRequestMetaFromCtx Get the request meta from context, it is empty outside of a request
+func GetRequestMetaFromCtx(ctx context.Context) caller.RequestMeta {
+	meta, _ := ctx.Value(RequestMetaCtxKey{}).(caller.RequestMeta)
+	return meta
+}
+
+// GetIPAddress Get the client ip address, resolved through our trusted proxies by the echo IPExtractor
 func GetIPAddress(c echo.Context) string {
-	return c.Request().RemoteAddr
+	return c.RealIP()
 }
 
 // ErrResponseWithLog Error response with logging error for echo context
@@ -116,9 +153,18 @@ func ErrResponseWithLog(ctx echo.Context, logger logger.Logger, err error) error
 		GetIPAddress(ctx),
 		err,
 	)
+	SetRetryAfter(ctx, err)
 	return ctx.JSON(httpErr.ErrorResponse(err))
 }
 
+// SetRetryAfter Set the Retry-After header when the error tells when to try again
+func SetRetryAfter(ctx echo.Context, err error) {
+	var retryErr interface{ RetryAfterSeconds() int }
+	if errors.As(err, &retryErr) {
+		ctx.Response().Header().Set("Retry-After", strconv.Itoa(retryErr.RetryAfterSeconds()))
+	}
+}
+
 // LogResponseError Error response with logging error for echo context
 func LogResponseError(ctx echo.Context, logger logger.Logger, err error) {
 	logger.Errorf(
@@ -151,17 +197,17 @@ func ReadImage(ctx echo.Context, field string) (*multipart.FileHeader, error) {
 	return image, nil
 }
 
-// SanitizeRequest Read sanitize and validate request
-func SanitizeRequest(ctx echo.Context, request interface{}) error {
+// SanitizeRequest Read sanitize and validate request, the raw fields such as passwords are kept as sent
+func SanitizeRequest(ctx echo.Context, request interface{}, raw ...string) error {
 	body, err := ioutil.ReadAll(ctx.Request().Body)
 	if err != nil {
 		return err
 	}
 	defer ctx.Request().Body.Close()
 
-	sanBody, err := sanitize.SanitizeJSON(body)
+	sanBody, err := sanitize.SanitizeJSON(body, raw...)
 	if err != nil {
-		return ctx.NoContent(http.StatusBadRequest)
+		return httpErr.NewBadRequestError(httpErr.ErrBadRequest.Error())
 	}
 
 	if err = json.Unmarshal(sanBody, request); err != nil {
@@ -12,8 +12,14 @@ import (
 // Use a single instance of Validate, it caches struct info
 var validate *validator.Validate
 
+// The characters the SA-MP client accepts in a nickname
+var sampUsernameRegexp = regexp.MustCompile(`^[A-Za-z0-9\[\]()$@._=]+$`)
+
 func init() {
 	validate = validator.New()
+	_ = validate.RegisterValidation("samp_username", func(fl validator.FieldLevel) bool {
+		return IsValidSampUsername(fl.Field().String())
+	})
 }
 
 // ValidateStruct Validate struct fields
@@ -27,6 +33,11 @@ func IsValidPhoneNumber(phoneNumber string) bool {
 	return regexPhoneNumber.MatchString(phoneNumber)
 }
 
+// IsValidSampUsername validate the characters of a SA-MP nickname
+func IsValidSampUsername(username string) bool {
+	return sampUsernameRegexp.MatchString(username)
+}
+
 // IsValidGender validate gender
 func IsValidGender(gender string) bool {
 	genderLower := strings.ToLower(gender)